@@ -0,0 +1,357 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/frictionlessdata/tableschema-go/schema"
+)
+
+// config holds the generation parameters.
+type config struct {
+	// Package is the name of the package of the generated file.
+	Package string
+	// Type is the name of the generated struct.
+	Type string
+	// Source is the path of the schema descriptor, used in comments.
+	Source string
+}
+
+// genField describes a struct field of the generated code.
+type genField struct {
+	Index    int
+	Name     string
+	GoName   string
+	GoType   string
+	Pointer  bool
+	Required bool
+	// Assert is the type asserted on the value returned by schema.Field.Cast. It is
+	// empty if the value is kept as interface{}.
+	Assert string
+	// Uncast is the expression used to uncast the value v. It is empty if
+	// schema.Field.Uncast is used.
+	Uncast string
+	// JSON is set if the value v is uncast to its JSON encoding.
+	JSON bool
+}
+
+// goTypes maps field types to the type of the values returned by schema.Field.Cast.
+var goTypes = map[schema.FieldType]string{
+	schema.IntegerType:     "int64",
+	schema.NumberType:      "float64",
+	schema.StringType:      "string",
+	schema.BooleanType:     "bool",
+	schema.DateType:        "time.Time",
+	schema.DateTimeType:    "time.Time",
+	schema.TimeType:        "time.Time",
+	schema.YearType:        "time.Time",
+	schema.YearMonthType:   "time.Time",
+	schema.DurationType:    "time.Duration",
+	schema.GeoPointType:    "schema.GeoPoint",
+	schema.ArrayType:       "[]interface{}",
+	schema.ObjectType:      "interface{}",
+	schema.AnyType:         "string",
+	schema.CategoricalType: "interface{}",
+}
+
+// listGoTypes maps list item types to the type of the values returned by schema.Field.Cast.
+var listGoTypes = map[schema.FieldType]string{
+	schema.StringType:   "[]string",
+	schema.IntegerType:  "[]int64",
+	schema.NumberType:   "[]float64",
+	schema.BooleanType:  "[]bool",
+	schema.DateType:     "[]time.Time",
+	schema.DateTimeType: "[]time.Time",
+	schema.TimeType:     "[]time.Time",
+}
+
+func goType(f *schema.Field) (string, error) {
+	if f.Type == schema.ListType {
+		itemType := f.ItemType
+		if itemType == "" {
+			itemType = schema.StringType
+		}
+		if t, ok := listGoTypes[itemType]; ok {
+			return t, nil
+		}
+		return "", fmt.Errorf("unsupported list item type %s", itemType)
+	}
+	if f.Type == schema.StringType && f.Format == "binary" {
+		return "[]byte", nil
+	}
+	if t, ok := goTypes[f.Type]; ok {
+		return t, nil
+	}
+	return "", fmt.Errorf("unsupported type %s", f.Type)
+}
+
+// timeLayouts maps time types to the layouts of their default formats.
+var timeLayouts = map[schema.FieldType]string{
+	schema.DateType:      "2006-01-02",
+	schema.TimeType:      "15:04:05",
+	schema.YearType:      "2006",
+	schema.YearMonthType: "2006-01",
+}
+
+// uncastExpr returns the expression used to uncast the value v of the field
+// without reflection. It returns an empty string if schema.Field.Uncast must be used.
+func uncastExpr(f *schema.Field) string {
+	if f.Format == "binary" && f.Type == schema.StringType {
+		return "base64.StdEncoding.EncodeToString(v)"
+	}
+	if f.Format != "" && f.Format != "default" {
+		return ""
+	}
+	switch f.Type {
+	case schema.StringType, schema.AnyType:
+		return "v"
+	case schema.IntegerType:
+		return "strconv.FormatInt(v, 10)"
+	case schema.NumberType:
+		return "strconv.FormatFloat(v, 'g', -1, 64)"
+	case schema.BooleanType:
+		// The formatted values are used only if they are read back as booleans.
+		if contains(f.TrueValues, "true") && contains(f.FalseValues, "false") {
+			return "strconv.FormatBool(v)"
+		}
+		if len(f.TrueValues) > 0 && len(f.FalseValues) > 0 {
+			return fmt.Sprintf("map[bool]string{true: %q, false: %q}[v]", f.TrueValues[0], f.FalseValues[0])
+		}
+	case schema.DateTimeType:
+		return "v.UTC().Format(time.RFC3339)"
+	case schema.DateType, schema.TimeType, schema.YearType, schema.YearMonthType:
+		return fmt.Sprintf("v.Format(%q)", timeLayouts[f.Type])
+	case schema.GeoPointType:
+		return `fmt.Sprintf("%v,%v", v.Lon, v.Lat)`
+	}
+	return ""
+}
+
+func contains(values []string, v string) bool {
+	for _, s := range values {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
+
+// initialisms are kept upper case in Go names.
+var initialisms = map[string]struct{}{
+	"API": {}, "CSV": {}, "HTML": {}, "HTTP": {}, "ID": {}, "IP": {}, "JSON": {}, "SQL": {}, "URI": {}, "URL": {}, "UUID": {},
+}
+
+// goName converts a field name into an exported Go identifier.
+func goName(name string) string {
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	var b strings.Builder
+	for _, w := range words {
+		if _, ok := initialisms[strings.ToUpper(w)]; ok {
+			b.WriteString(strings.ToUpper(w))
+			continue
+		}
+		r := []rune(w)
+		b.WriteString(string(unicode.ToUpper(r[0])) + string(r[1:]))
+	}
+	ret := b.String()
+	if ret == "" || !unicode.IsLetter([]rune(ret)[0]) {
+		ret = "F" + ret
+	}
+	return ret
+}
+
+// generate returns the Go source code of a struct describing the rows of the table
+// described by the schema, along with the CastRow and UncastRow methods.
+func generate(cfg config, s *schema.Schema, descriptor []byte) ([]byte, error) {
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	primaryKey := make(map[string]struct{}, len(s.PrimaryKeys))
+	for _, pk := range s.PrimaryKeys {
+		primaryKey[pk] = struct{}{}
+	}
+	names := make(map[string]int)
+	imports := map[string]struct{}{"fmt": {}, "strings": {}}
+	fields := make([]genField, len(s.Fields))
+	for i := range s.Fields {
+		f := &s.Fields[i]
+		t, err := goType(f)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %v", f.Name, err)
+		}
+		if strings.Contains(t, "time.") {
+			imports["time"] = struct{}{}
+		}
+		_, pk := primaryKey[f.Name]
+		gf := genField{
+			Index:    i,
+			Name:     f.Name,
+			GoName:   goName(f.Name),
+			GoType:   t,
+			Required: f.Constraints.Required || pk,
+			Uncast:   uncastExpr(f),
+			JSON:     f.Type == schema.ArrayType || f.Type == schema.ObjectType,
+		}
+		if t != "interface{}" {
+			gf.Assert = t
+			gf.Pointer = !gf.Required
+		}
+		if strings.HasPrefix(gf.Uncast, "strconv.") {
+			imports["strconv"] = struct{}{}
+		}
+		if strings.HasPrefix(gf.Uncast, "base64.") {
+			imports["encoding/base64"] = struct{}{}
+		}
+		if gf.JSON {
+			imports["encoding/json"] = struct{}{}
+		}
+		// Deduplicating Go names.
+		if n, ok := names[gf.GoName]; ok {
+			names[gf.GoName] = n + 1
+			gf.GoName = fmt.Sprintf("%s%d", gf.GoName, n+1)
+		} else {
+			names[gf.GoName] = 1
+		}
+		fields[i] = gf
+	}
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, descriptor); err != nil {
+		return nil, err
+	}
+	var importList []string
+	for _, p := range []string{"encoding/base64", "encoding/json", "fmt", "strconv", "strings", "time"} {
+		if _, ok := imports[p]; ok {
+			importList = append(importList, p)
+		}
+	}
+	var buf bytes.Buffer
+	err := codeTemplate.Execute(&buf, struct {
+		config
+		SchemaVar  string
+		Descriptor string
+		Imports    []string
+		Fields     []genField
+	}{
+		config:     cfg,
+		SchemaVar:  strings.ToLower(cfg.Type[:1]) + cfg.Type[1:] + "Schema",
+		Descriptor: strconv.Quote(compact.String()),
+		Imports:    importList,
+		Fields:     fields,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return format.Source(buf.Bytes())
+}
+
+var codeTemplate = template.Must(template.New("code").Parse(`// Code generated by tableschema-gen. DO NOT EDIT.
+{{- if .Source}}
+// Source: {{.Source}}
+{{- end}}
+
+package {{.Package}}
+
+import (
+{{- range .Imports}}
+	"{{.}}"
+{{- end}}
+
+	"github.com/frictionlessdata/tableschema-go/schema"
+)
+
+// {{.Type}} holds a row of a table described by the {{.SchemaVar}} schema.
+type {{.Type}} struct {
+{{- range .Fields}}
+	{{.GoName}} {{if .Pointer}}*{{end}}{{.GoType}} ` + "`" + `tableheader:"{{.Name}}"` + "`" + `
+{{- end}}
+}
+
+// {{.SchemaVar}} is the schema {{.Type}} was generated from.
+var {{.SchemaVar}} = func() *schema.Schema {
+	s, err := schema.Read(strings.NewReader({{.Descriptor}}))
+	if err != nil {
+		panic(err)
+	}
+	return s
+}()
+
+// CastRow casts the passed-in row into r. Cells are cast using the schema fields, in
+// schema declaration order. Missing cells of optional fields are cast to nil.
+func (r *{{.Type}}) CastRow(row []string) error {
+	if len(row) != {{len .Fields}} {
+		return fmt.Errorf("the row with %d values does not match the %d fields in the schema", len(row), {{len .Fields}})
+	}
+{{- range .Fields}}
+	{
+		v, err := {{$.SchemaVar}}.Fields[{{.Index}}].Cast(row[{{.Index}}])
+		if err != nil {
+			return err
+		}
+{{- if .Required}}
+		if v == nil {
+			return &schema.RequiredError{Field: {{printf "%q" .Name}}, Value: row[{{.Index}}]}
+		}
+{{- end}}
+{{- if not .Assert}}
+		r.{{.GoName}} = v
+{{- else if .Pointer}}
+		if v == nil {
+			r.{{.GoName}} = nil
+		} else {
+			c := v.({{.Assert}})
+			r.{{.GoName}} = &c
+		}
+{{- else}}
+		r.{{.GoName}} = v.({{.Assert}})
+{{- end}}
+	}
+{{- end}}
+	return nil
+}
+
+// UncastRow uncasts r into a row, in schema declaration order. Nil values are uncast
+// to the first missing value of the field.
+func (r *{{.Type}}) UncastRow() ([]string, error) {
+	row := make([]string, {{len .Fields}})
+{{- range .Fields}}
+{{- if or .Pointer (not .Assert)}}
+	if r.{{.GoName}} == nil {
+		mv, _ := {{$.SchemaVar}}.MissingValue({{printf "%q" .Name}})
+{{- if .Required}}
+		return nil, &schema.RequiredError{Field: {{printf "%q" .Name}}, Value: mv}
+{{- else}}
+		row[{{.Index}}] = mv
+{{- end}}
+	} else {
+{{- else}}
+	{
+{{- end}}
+		v := {{if .Pointer}}*{{end}}r.{{.GoName}}
+{{- if .Uncast}}
+		row[{{.Index}}] = {{.Uncast}}
+{{- else if .JSON}}
+		b, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		row[{{.Index}}] = string(b)
+{{- else}}
+		cell, err := {{$.SchemaVar}}.Fields[{{.Index}}].Uncast(v)
+		if err != nil {
+			return nil, err
+		}
+		row[{{.Index}}] = cell
+{{- end}}
+	}
+{{- end}}
+	return row, nil
+}
+`))
@@ -0,0 +1,204 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/frictionlessdata/tableschema-go/schema"
+	"github.com/matryer/is"
+)
+
+func TestGoName(t *testing.T) {
+	data := []struct {
+		in   string
+		want string
+	}{
+		{"name", "Name"},
+		{"first_name", "FirstName"},
+		{"user id", "UserID"},
+		{"url", "URL"},
+		{"camelCase", "CamelCase"},
+		{"1st", "F1st"},
+		{"", "F"},
+		{"ação", "Ação"},
+	}
+	for _, d := range data {
+		t.Run(d.in, func(t *testing.T) {
+			is := is.New(t)
+			is.Equal(goName(d.in), d.want)
+		})
+	}
+}
+
+func generateFromDescriptor(t *testing.T, descriptor string) string {
+	is := is.New(t)
+	s, err := schema.Read(strings.NewReader(descriptor))
+	is.NoErr(err)
+	code, err := generate(config{Package: "foo", Type: "Row"}, s, []byte(descriptor))
+	is.NoErr(err)
+	return string(code)
+}
+
+func TestGenerate(t *testing.T) {
+	t.Run("Example", func(t *testing.T) {
+		// The example code must be regenerated when the generator changes.
+		is := is.New(t)
+		descriptor, err := ioutil.ReadFile("../../examples/codegen/schema.json")
+		is.NoErr(err)
+		s, err := schema.Read(bytes.NewReader(descriptor))
+		is.NoErr(err)
+		got, err := generate(config{Package: "main", Type: "Capital", Source: "schema.json"}, s, descriptor)
+		is.NoErr(err)
+		want, err := ioutil.ReadFile("../../examples/codegen/capital_tableschema.go")
+		is.NoErr(err)
+		is.Equal(string(got), string(want))
+	})
+	t.Run("Types", func(t *testing.T) {
+		is := is.New(t)
+		code := generateFromDescriptor(t, `{"fields":[
+			{"name":"a", "type":"integer", "constraints":{"required":true}},
+			{"name":"b", "type":"number"},
+			{"name":"c", "type":"string", "format":"binary"},
+			{"name":"d", "type":"boolean"},
+			{"name":"e", "type":"datetime"},
+			{"name":"f", "type":"duration"},
+			{"name":"g", "type":"geopoint"},
+			{"name":"h", "type":"object"},
+			{"name":"i", "type":"array"},
+			{"name":"j", "type":"list", "itemType":"integer"},
+			{"name":"k", "type":"categorical", "categories":["x"], "constraints":{"required":true}},
+			{"name":"l", "type":"any"},
+			{"name":"a_", "type":"string"}
+		]}`)
+		for _, want := range []string{
+			"A  int64 `tableheader:\"a\"`",
+			"B  *float64 `tableheader:\"b\"`",
+			"C  *[]byte `tableheader:\"c\"`",
+			"D  *bool `tableheader:\"d\"`",
+			"E  *time.Time `tableheader:\"e\"`",
+			"F  *time.Duration `tableheader:\"f\"`",
+			"G  *schema.GeoPoint `tableheader:\"g\"`",
+			"H  interface{} `tableheader:\"h\"`",
+			"I  *[]interface{} `tableheader:\"i\"`",
+			"J  *[]int64 `tableheader:\"j\"`",
+			"K  interface{} `tableheader:\"k\"`",
+			"L  *string `tableheader:\"l\"`",
+			"A2 *string `tableheader:\"a_\"`",
+			`return nil, &schema.RequiredError{Field: "k", Value: mv}`,
+		} {
+			is.True(strings.Contains(strings.Join(strings.Fields(code), " "), strings.Join(strings.Fields(want), " ")))
+		}
+	})
+	t.Run("InvalidSchema", func(t *testing.T) {
+		is := is.New(t)
+		s := &schema.Schema{Fields: []schema.Field{{Name: "a", Type: "integr"}}}
+		_, err := generate(config{Package: "foo", Type: "Row"}, s, []byte(`{}`))
+		is.True(err != nil)
+	})
+}
+
+// roundTripMain casts the rows using the generated code, uncasts them and checks
+// that casting the uncast rows gives back the same values.
+const roundTripMain = `package main
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+)
+
+func main() {
+	for _, row := range %#v {
+		var r Row
+		if err := r.CastRow(row); err != nil {
+			fmt.Printf("cast row:%%v err:%%v", row, err)
+			os.Exit(1)
+		}
+		cells, err := r.UncastRow()
+		if err != nil {
+			fmt.Printf("uncast row:%%v err:%%v", row, err)
+			os.Exit(1)
+		}
+		var got Row
+		if err := got.CastRow(cells); err != nil {
+			fmt.Printf("cast uncast row:%%v err:%%v", cells, err)
+			os.Exit(1)
+		}
+		if !reflect.DeepEqual(r, got) {
+			fmt.Printf("row:%%v uncast:%%v got:%%+v want:%%+v", row, cells, got, r)
+			os.Exit(1)
+		}
+	}
+}
+`
+
+func TestGenerate_RoundTrip(t *testing.T) {
+	if testing.Short() {
+		t.Skip("compiles generated code")
+	}
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go command not found")
+	}
+	data := []struct {
+		Desc       string
+		Descriptor string
+		Rows       [][]string
+	}{
+		{"Example", "", [][]string{{"1", "39.00", "http://www.test.com", "1923-10-29"}, {"2", "NA", "", "NA"}}},
+		{"Types", `{"fields":[
+			{"name":"a", "type":"integer", "constraints":{"required":true}},
+			{"name":"b", "type":"number"},
+			{"name":"c", "type":"string", "format":"binary"},
+			{"name":"d", "type":"boolean"},
+			{"name":"e", "type":"datetime"},
+			{"name":"f", "type":"duration"},
+			{"name":"g", "type":"geopoint"},
+			{"name":"h", "type":"object"},
+			{"name":"i", "type":"array"},
+			{"name":"j", "type":"list", "itemType":"integer"},
+			{"name":"k", "type":"categorical", "categories":["x"], "constraints":{"required":true}},
+			{"name":"l", "type":"any"},
+			{"name":"m", "type":"date"},
+			{"name":"n", "type":"time"},
+			{"name":"o", "type":"year"},
+			{"name":"p", "type":"yearmonth"},
+			{"name":"q", "type":"boolean", "trueValues":["Y"], "falseValues":["N"]}
+		]}`, [][]string{
+			{"1", "1.5", "YQ==", "true", "2015-01-02T10:11:12Z", "P1DT2H", "10,20", `{"a":[1,"b"]}`, `["a",1]`, "1,2", "x", "z", "2015-01-02", "10:11:12", "2015", "2015-01", "Y"},
+			{"2", "", "", "", "", "", "", "", "", "", "x", "", "", "", "", "", ""},
+		}},
+	}
+	for _, d := range data {
+		t.Run(d.Desc, func(t *testing.T) {
+			is := is.New(t)
+			descriptor := []byte(d.Descriptor)
+			if d.Descriptor == "" {
+				descriptor, err = ioutil.ReadFile("../../examples/codegen/schema.json")
+				is.NoErr(err)
+			}
+			s, err := schema.Read(bytes.NewReader(descriptor))
+			is.NoErr(err)
+			code, err := generate(config{Package: "main", Type: "Row"}, s, descriptor)
+			is.NoErr(err)
+			// The directory must be inside the module, so the generated code imports this
+			// version of the schema package.
+			dir, err := ioutil.TempDir(".", "roundtrip")
+			is.NoErr(err)
+			defer os.RemoveAll(dir)
+			is.NoErr(ioutil.WriteFile(filepath.Join(dir, "row_tableschema.go"), code, 0644))
+			is.NoErr(ioutil.WriteFile(filepath.Join(dir, "main.go"), []byte(fmt.Sprintf(roundTripMain, d.Rows)), 0644))
+			cmd := exec.Command(goBin, "run", ".")
+			cmd.Dir = dir
+			if out, err := cmd.CombinedOutput(); err != nil {
+				t.Fatalf("round trip failed: %v\n%s", err, out)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+// Command tableschema-gen generates a Go struct describing the rows of a table from its
+// Table Schema descriptor. The struct fields are tagged with tableheader, optional fields
+// are pointers, and the generated CastRow and UncastRow methods do not rely on reflection.
+// The only exception is uncasting durations, lists, categorical values and values with
+// custom formats, which is left to schema.Field.Uncast.
+//
+// It is meant to be used with go generate:
+//
+//	//go:generate go run github.com/frictionlessdata/tableschema-go/cmd/tableschema-gen -schema schema.json -type Capital
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/frictionlessdata/tableschema-go/schema"
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("tableschema-gen: ")
+	schemaPath := flag.String("schema", "", "path to the schema descriptor (required)")
+	typeName := flag.String("type", "", "name of the generated struct (required)")
+	pkg := flag.String("package", os.Getenv("GOPACKAGE"), "package of the generated file, defaults to $GOPACKAGE or main")
+	out := flag.String("o", "", "output file, defaults to <type>_tableschema.go")
+	flag.Parse()
+
+	if *schemaPath == "" || *typeName == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if *pkg == "" {
+		*pkg = "main"
+	}
+	if *out == "" {
+		*out = strings.ToLower(*typeName) + "_tableschema.go"
+	}
+	descriptor, err := ioutil.ReadFile(*schemaPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	s, err := schema.Read(bytes.NewReader(descriptor))
+	if err != nil {
+		log.Fatalf("error reading schema %s: %v", *schemaPath, err)
+	}
+	code, err := generate(config{Package: *pkg, Type: *typeName, Source: filepath.ToSlash(*schemaPath)}, s, descriptor)
+	if err != nil {
+		log.Fatalf("error generating code: %v", err)
+	}
+	if err := ioutil.WriteFile(*out, code, 0644); err != nil {
+		log.Fatal(err)
+	}
+	fmt.Fprintf(os.Stderr, "tableschema-gen: wrote %s\n", *out)
+}
@@ -0,0 +1,261 @@
+package csv
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// headerDetectionRows is the maximum number of rows compared with the first row by
+// DetectHeaders.
+const headerDetectionRows = 100
+
+// readRows reads the first n rows of the CSV, including the header rows.
+func (table *Table) readRows(n int) ([][]string, error) {
+	src, err := table.source()
+	if err != nil {
+		return nil, err
+	}
+	iter := newIterator(src, table.dialect, 0)
+	defer iter.Close()
+	var rows [][]string
+	for len(rows) < n && iter.Next() {
+		rows = append(rows, iter.Row())
+	}
+	return rows, iter.Err()
+}
+
+// DetectHeaders uses the first line of the CSV as table headers if it looks like a
+// header row, which is then skipped during iteration. Otherwise, all lines are data
+// and the headers are named after the column positions: field1, field2 and so on.
+//
+// The first line is taken as headers when, for most of the columns, its cell does
+// not look like the cells below it: for instance, a text cell atop a column of
+// numbers, dates or booleans, or a cell whose length differs from the fixed length
+// of the text cells below it. A CSV made of a single line is taken as headers.
+func DetectHeaders() CreationOpts {
+	return func(t *Table) error {
+		rows, err := t.readRows(headerDetectionRows + 1)
+		if err != nil {
+			return err
+		}
+		t.headers, t.headerRows = nil, 0
+		switch {
+		case len(rows) == 0:
+		case len(rows) == 1 || hasHeader(rows[0], rows[1:]):
+			t.headers, t.headerRows = rows[0], 1
+		default:
+			t.headers = make([]string, len(rows[0]))
+			for i := range t.headers {
+				t.headers[i] = generatedHeader(i)
+			}
+		}
+		return nil
+	}
+}
+
+// LoadMultiRowHeaders uses the first n lines of the CSV as table headers. The headers
+// are the non-blank cells of each column joined by a space. As headers spanning many
+// columns (merged cells) are usually written once, blank cells of all but the last
+// header line take the value of the cell at their left. For instance, the lines
+// "Sales,,Costs" and "2019,2020,2020" result in the headers "Sales 2019",
+// "Sales 2020" and "Costs 2020". The header lines are skipped during iteration.
+func LoadMultiRowHeaders(n int) CreationOpts {
+	return func(t *Table) error {
+		if n <= 0 {
+			return fmt.Errorf("number of header rows must be positive, got:%d", n)
+		}
+		rows, err := t.readRows(n)
+		if err != nil {
+			return err
+		}
+		t.headers, t.headerRows = joinHeaderRows(rows), n
+		return nil
+	}
+}
+
+// NormalizeHeaders cleans up the table headers: spaces around them are trimmed,
+// blank headers are named after their positions (field1, field2 and so on) and
+// duplicate headers get a suffix with their occurrence number (for instance, name,
+// name_2). It applies to the headers set by the preceding options, so it must be
+// declared after them.
+func NormalizeHeaders() CreationOpts {
+	return func(t *Table) error {
+		t.headers = normalizeHeaders(t.headers, strings.TrimSpace)
+		return nil
+	}
+}
+
+// SnakeCaseHeaders converts the table headers to snake_case: "First Name",
+// "firstName" and "first-name" all become "first_name". Headers are then normalized
+// as done by NormalizeHeaders. It applies to the headers set by the preceding
+// options, so it must be declared after them.
+func SnakeCaseHeaders() CreationOpts {
+	return func(t *Table) error {
+		t.headers = normalizeHeaders(t.headers, snakeCase)
+		return nil
+	}
+}
+
+func generatedHeader(i int) string {
+	return "field" + strconv.Itoa(i+1)
+}
+
+func joinHeaderRows(rows [][]string) []string {
+	n := 0
+	for _, row := range rows {
+		if len(row) > n {
+			n = len(row)
+		}
+	}
+	headers := make([]string, n)
+	for r, row := range rows {
+		last := ""
+		for i := 0; i < n; i++ {
+			cell := ""
+			if i < len(row) {
+				cell = strings.TrimSpace(row[i])
+			}
+			if cell == "" && r < len(rows)-1 {
+				cell = last
+			}
+			last = cell
+			switch {
+			case cell == "":
+			case headers[i] == "":
+				headers[i] = cell
+			default:
+				headers[i] += " " + cell
+			}
+		}
+	}
+	return headers
+}
+
+func normalizeHeaders(headers []string, clean func(string) string) []string {
+	if headers == nil {
+		return nil
+	}
+	ret := make([]string, len(headers))
+	seen := make(map[string]struct{}, len(headers))
+	for i, h := range headers {
+		h = clean(h)
+		if h == "" {
+			h = generatedHeader(i)
+		}
+		name := h
+		for n := 2; ; n++ {
+			if _, ok := seen[name]; !ok {
+				break
+			}
+			name = h + "_" + strconv.Itoa(n)
+		}
+		seen[name] = struct{}{}
+		ret[i] = name
+	}
+	return ret
+}
+
+func snakeCase(s string) string {
+	var b strings.Builder
+	runes := []rune(strings.TrimSpace(s))
+	sep := false
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			sep = b.Len() > 0
+			continue
+		}
+		// Word boundaries of camelCase and CamelCase words, as in firstName or HTTPServer.
+		if unicode.IsUpper(r) && i > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1]) ||
+			(i+1 < len(runes) && unicode.IsUpper(runes[i-1]) && unicode.IsLower(runes[i+1]))) {
+			sep = b.Len() > 0
+		}
+		if sep {
+			b.WriteByte('_')
+			sep = false
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
+
+// cellKind is a coarse type of a cell, used to tell header rows from data rows.
+type cellKind int
+
+const (
+	blankCell cellKind = iota
+	textCell
+	numberCell
+	booleanCell
+	dateCell
+)
+
+func kindOf(cell string) cellKind {
+	cell = strings.TrimSpace(cell)
+	if cell == "" {
+		return blankCell
+	}
+	if _, err := strconv.ParseFloat(cell, 64); err == nil {
+		return numberCell
+	}
+	switch strings.ToLower(cell) {
+	case "true", "false":
+		return booleanCell
+	}
+	for _, layout := range []string{"2006-01-02", time.RFC3339} {
+		if _, err := time.Parse(layout, cell); err == nil {
+			return dateCell
+		}
+	}
+	return textCell
+}
+
+// hasHeader returns true if the first row does not look like the following rows in
+// most columns. Each column votes for or against the first row being a header.
+func hasHeader(first []string, rows [][]string) bool {
+	votes := 0
+	for i, cell := range first {
+		kind, length, sameLength := blankCell, -1, true
+		consistent := true
+		for _, row := range rows {
+			if i >= len(row) {
+				continue
+			}
+			k := kindOf(row[i])
+			if k == blankCell {
+				continue
+			}
+			if kind == blankCell {
+				kind = k
+			}
+			if k != kind {
+				consistent = false
+				break
+			}
+			switch n := len([]rune(row[i])); {
+			case length == -1:
+				length = n
+			case length != n:
+				sameLength = false
+			}
+		}
+		switch {
+		case !consistent || kind == blankCell:
+		case kind != textCell:
+			if kindOf(cell) != kind {
+				votes++
+			} else {
+				votes--
+			}
+		case sameLength:
+			if len([]rune(cell)) != length {
+				votes++
+			} else {
+				votes--
+			}
+		}
+	}
+	return votes > 0
+}
@@ -0,0 +1,75 @@
+package csv
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestDetectHeaders(t *testing.T) {
+	data := []struct {
+		desc    string
+		in      string
+		headers []string
+		rows    [][]string
+	}{
+		{"Empty", "", nil, nil},
+		{"SingleRow", "name,age", []string{"name", "age"}, nil},
+		{"TextOverNumbers", "name,age\nfoo,25\nbar,48", []string{"name", "age"}, [][]string{{"foo", "25"}, {"bar", "48"}}},
+		{"TextOverDates", "when\n2020-01-02\n2021-03-04", []string{"when"}, [][]string{{"2020-01-02"}, {"2021-03-04"}}},
+		{"FixedLengthCodes", "country,code\nBrazil,BR\nFrance,FR", []string{"country", "code"}, [][]string{{"Brazil", "BR"}, {"France", "FR"}}},
+		{"NoHeaders", "foo,25\nbar,48", []string{"field1", "field2"}, [][]string{{"foo", "25"}, {"bar", "48"}}},
+		{"NoHeadersTypedColumns", "1,true\n2,false\n3,true", []string{"field1", "field2"}, [][]string{{"1", "true"}, {"2", "false"}, {"3", "true"}}},
+	}
+	for _, d := range data {
+		t.Run(d.desc, func(t *testing.T) {
+			is := is.New(t)
+			table, err := NewTable(FromString(d.in), DetectHeaders())
+			is.NoErr(err)
+			rows, err := table.ReadAll()
+			is.NoErr(err)
+			if !reflect.DeepEqual(table.Headers(), d.headers) || !reflect.DeepEqual(rows, d.rows) {
+				t.Fatalf("got headers:%q rows:%q want headers:%q rows:%q", table.Headers(), rows, d.headers, d.rows)
+			}
+		})
+	}
+}
+
+func TestLoadMultiRowHeaders(t *testing.T) {
+	t.Run("Join", func(t *testing.T) {
+		is := is.New(t)
+		in := "Sales,,Costs,\n2019,2020,2020, Total \n10,20,5,35"
+		table, err := NewTable(FromString(in), LoadMultiRowHeaders(2))
+		is.NoErr(err)
+		is.Equal(table.Headers(), []string{"Sales 2019", "Sales 2020", "Costs 2020", "Costs Total"})
+		rows, err := table.ReadAll()
+		is.NoErr(err)
+		is.Equal(rows, [][]string{{"10", "20", "5", "35"}})
+	})
+	t.Run("InvalidNumberOfRows", func(t *testing.T) {
+		is := is.New(t)
+		_, err := NewTable(FromString("a\nb"), LoadMultiRowHeaders(0))
+		is.True(err != nil)
+	})
+}
+
+func TestNormalizeHeaders(t *testing.T) {
+	is := is.New(t)
+	table, err := NewTable(FromString(" name ,,name,age,name\nfoo,1,bar,2,baz"), LoadHeaders(), NormalizeHeaders())
+	is.NoErr(err)
+	is.Equal(table.Headers(), []string{"name", "field2", "name_2", "age", "name_3"})
+	col, err := table.ReadColumn("name_2")
+	is.NoErr(err)
+	is.Equal(col, []string{"bar"})
+}
+
+func TestSnakeCaseHeaders(t *testing.T) {
+	is := is.New(t)
+	table, err := NewTable(
+		FromString("First Name,firstName,HTTPServer,Price (USD),  ,x\n"),
+		LoadHeaders(),
+		SnakeCaseHeaders())
+	is.NoErr(err)
+	is.Equal(table.Headers(), []string{"first_name", "first_name_2", "http_server", "price_usd", "field5", "x"})
+}
@@ -7,8 +7,8 @@ import (
 )
 
 const (
-	dontSkipHeaders = false
-	skipHeaders     = true
+	dontSkipHeaders = 0
+	skipHeaders     = 1
 )
 
 func TestNewIterator(t *testing.T) {
@@ -46,6 +46,9 @@ func TestIterator_Next(t *testing.T) {
 		defer iter.Close()
 		is.True(iter.Next())
 		is.True(iter.Next())
+		is.Equal(iter.Row(), []string{"bar", "bez", "boo"})
+		is.True(!iter.Next())
+		is.NoErr(iter.Err())
 	})
 }
 
@@ -19,10 +19,12 @@ import (
 
 // Table represents a Table backed by a CSV physical representation.
 type Table struct {
-	headers     []string
-	source      Source
-	skipHeaders bool
-	dialect     dialect
+	headers []string
+	source  Source
+	dialect dialect
+	// headerRows is the number of rows holding the headers, which are skipped
+	// during iteration.
+	headerRows int
 }
 
 // dialect represents CSV dialect configuration options.
@@ -62,7 +64,7 @@ func (table *Table) Iter() (table.Iterator, error) {
 	if err != nil {
 		return nil, err
 	}
-	return newIterator(src, table.dialect, table.skipHeaders), nil
+	return newIterator(src, table.dialect, table.headerRows), nil
 }
 
 // ReadAll reads all rows from the table and return it as strings.
@@ -119,14 +121,17 @@ func (table *Table) String() string {
 	return buf.String()
 }
 
-func newIterator(source io.ReadCloser, dialect dialect, skipHeaders bool) *csvIterator {
+func newIterator(source io.ReadCloser, dialect dialect, skipRows int) *csvIterator {
 	r := csv.NewReader(source)
 	r.Comma = dialect.delimiter
 	r.TrimLeadingSpace = dialect.skipInitialSpace
+	// Ragged rows are returned as they are. They are handled by the consumers of the
+	// table, for instance, as defined by schema.RaggedRows.
+	r.FieldsPerRecord = -1
 	return &csvIterator{
-		source:      source,
-		reader:      r,
-		skipHeaders: skipHeaders,
+		source:   source,
+		reader:   r,
+		skipRows: skipRows,
 	}
 }
 
@@ -134,9 +139,9 @@ type csvIterator struct {
 	reader *csv.Reader
 	source io.ReadCloser
 
-	current     []string
-	err         error
-	skipHeaders bool
+	current  []string
+	err      error
+	skipRows int
 }
 
 func (i *csvIterator) Next() bool {
@@ -146,15 +151,11 @@ func (i *csvIterator) Next() bool {
 	var err error
 	i.current, err = i.reader.Read()
 	if err != nil && err != io.EOF {
-		// For reference: https://github.com/frictionlessdata/tableschema-go/issues/73
-		if newErr, ok := err.(*csv.ParseError); ok && newErr.Err == csv.ErrFieldCount {
-			return true
-		}
 		i.err = err
 	}
-	if i.skipHeaders {
-		i.skipHeaders = false
-		i.Next()
+	if err == nil && i.skipRows > 0 {
+		i.skipRows--
+		return i.Next()
 	}
 	return err == nil
 }
@@ -239,15 +240,14 @@ func errorSource() Source {
 // The header line will be skipped during iteration
 func LoadHeaders() CreationOpts {
 	return func(reader *Table) error {
-		reader.skipHeaders = false
-		iter, err := reader.Iter()
+		rows, err := reader.readRows(1)
 		if err != nil {
 			return err
 		}
-		if iter.Next() {
-			reader.headers = iter.Row()
+		if len(rows) > 0 {
+			reader.headers = rows[0]
 		}
-		reader.skipHeaders = true
+		reader.headerRows = 1
 		return nil
 	}
 }
@@ -0,0 +1,121 @@
+// Code generated by tableschema-gen. DO NOT EDIT.
+// Source: schema.json
+
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/frictionlessdata/tableschema-go/schema"
+)
+
+// Capital holds a row of a table described by the capitalSchema schema.
+type Capital struct {
+	ID      int64      `tableheader:"ID"`
+	Capital *float64   `tableheader:"Capital"`
+	URL     *string    `tableheader:"URL"`
+	Founded *time.Time `tableheader:"Founded"`
+}
+
+// capitalSchema is the schema Capital was generated from.
+var capitalSchema = func() *schema.Schema {
+	s, err := schema.Read(strings.NewReader("{\"fields\":[{\"name\":\"ID\",\"type\":\"integer\",\"constraints\":{\"unique\":true}},{\"name\":\"Capital\",\"type\":\"number\",\"constraints\":{\"minimum\":\"0\"}},{\"name\":\"URL\",\"type\":\"string\",\"format\":\"uri\"},{\"name\":\"Founded\",\"type\":\"date\"}],\"primaryKey\":\"ID\",\"missingValues\":[\"\",\"NA\"]}"))
+	if err != nil {
+		panic(err)
+	}
+	return s
+}()
+
+// CastRow casts the passed-in row into r. Cells are cast using the schema fields, in
+// schema declaration order. Missing cells of optional fields are cast to nil.
+func (r *Capital) CastRow(row []string) error {
+	if len(row) != 4 {
+		return fmt.Errorf("the row with %d values does not match the %d fields in the schema", len(row), 4)
+	}
+	{
+		v, err := capitalSchema.Fields[0].Cast(row[0])
+		if err != nil {
+			return err
+		}
+		if v == nil {
+			return &schema.RequiredError{Field: "ID", Value: row[0]}
+		}
+		r.ID = v.(int64)
+	}
+	{
+		v, err := capitalSchema.Fields[1].Cast(row[1])
+		if err != nil {
+			return err
+		}
+		if v == nil {
+			r.Capital = nil
+		} else {
+			c := v.(float64)
+			r.Capital = &c
+		}
+	}
+	{
+		v, err := capitalSchema.Fields[2].Cast(row[2])
+		if err != nil {
+			return err
+		}
+		if v == nil {
+			r.URL = nil
+		} else {
+			c := v.(string)
+			r.URL = &c
+		}
+	}
+	{
+		v, err := capitalSchema.Fields[3].Cast(row[3])
+		if err != nil {
+			return err
+		}
+		if v == nil {
+			r.Founded = nil
+		} else {
+			c := v.(time.Time)
+			r.Founded = &c
+		}
+	}
+	return nil
+}
+
+// UncastRow uncasts r into a row, in schema declaration order. Nil values are uncast
+// to the first missing value of the field.
+func (r *Capital) UncastRow() ([]string, error) {
+	row := make([]string, 4)
+	{
+		v := r.ID
+		row[0] = strconv.FormatInt(v, 10)
+	}
+	if r.Capital == nil {
+		mv, _ := capitalSchema.MissingValue("Capital")
+		row[1] = mv
+	} else {
+		v := *r.Capital
+		row[1] = strconv.FormatFloat(v, 'g', -1, 64)
+	}
+	if r.URL == nil {
+		mv, _ := capitalSchema.MissingValue("URL")
+		row[2] = mv
+	} else {
+		v := *r.URL
+		cell, err := capitalSchema.Fields[2].Uncast(v)
+		if err != nil {
+			return nil, err
+		}
+		row[2] = cell
+	}
+	if r.Founded == nil {
+		mv, _ := capitalSchema.MissingValue("Founded")
+		row[3] = mv
+	} else {
+		v := *r.Founded
+		row[3] = v.Format("2006-01-02")
+	}
+	return row, nil
+}
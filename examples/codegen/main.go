@@ -0,0 +1,34 @@
+package main
+
+import (
+	"log"
+
+	"github.com/frictionlessdata/tableschema-go/csv"
+)
+
+//go:generate go run ../../cmd/tableschema-gen -schema schema.json -type Capital
+
+// Example of how to use a struct generated from a schema, which casts rows without reflection.
+func main() {
+	table, err := csv.NewTable(csv.FromFile("capital.csv"), csv.LoadHeaders())
+	if err != nil {
+		log.Fatalf("Error creating table: %q", err)
+	}
+	iter, err := table.Iter()
+	if err != nil {
+		log.Fatalf("Error iterating over table: %q", err)
+	}
+	defer iter.Close()
+	var capital Capital
+	for iter.Next() {
+		if err := capital.CastRow(iter.Row()); err != nil {
+			log.Fatalf("Couldn't cast row:%v err:%q", iter.Row(), err)
+		}
+		log.Printf("Cast Row: %+v\n", capital)
+		row, err := capital.UncastRow()
+		if err != nil {
+			log.Fatalf("Couldn't uncast row:%+v err:%q", capital, err)
+		}
+		log.Printf("Uncast Row: %v\n", row)
+	}
+}
@@ -5,14 +5,48 @@ import (
 	"fmt"
 )
 
-func castArray(value string) (interface{}, error) {
-	var obj interface{}
-	if err := json.Unmarshal([]byte(value), &obj); err != nil {
+// castArray casts a JSON array. If item is not nil, every element is cast using
+// the item field descriptor, as if it were a cell of its own. The minLength and
+// maxLength constraints are checked against the number of elements.
+func castArray(value string, item *Field, c Constraints) (interface{}, error) {
+	var raw []json.RawMessage
+	if err := json.Unmarshal([]byte(value), &raw); err != nil || raw == nil {
+		return nil, fmt.Errorf("%s is not an JSON array", value)
+	}
+	if err := checkLengthConstraints(value, len(raw), c); err != nil {
 		return nil, err
 	}
-	arr, ok := obj.([]interface{})
-	if !ok {
-		return nil, fmt.Errorf("%s is not an JSON array", value)
+	arr := make([]interface{}, len(raw))
+	for i := range raw {
+		if item == nil {
+			if err := json.Unmarshal(raw[i], &arr[i]); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		v, err := castJSONValue(item, raw[i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid array item %d: %v", i, err)
+		}
+		arr[i] = v
 	}
 	return arr, nil
 }
+
+// castJSONValue casts a JSON encoded value using the passed-in field. JSON strings are
+// unquoted before casting, other values are cast using their JSON representation.
+// Nulls and missing values of the field (e.g. "") are cast to nil.
+func castJSONValue(f *Field, raw json.RawMessage) (interface{}, error) {
+	if string(raw) == "null" {
+		if f.Constraints.Required {
+			return nil, &RequiredError{Field: f.Name, Value: string(raw)}
+		}
+		return nil, nil
+	}
+	cell := string(raw)
+	var str string
+	if err := json.Unmarshal(raw, &str); err == nil {
+		cell = str
+	}
+	return f.Cast(cell)
+}
@@ -0,0 +1,61 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestCastArray(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		data := []struct {
+			desc  string
+			value string
+			item  *Field
+			c     Constraints
+			want  []interface{}
+		}{
+			{"NoItem", `["foo", 1]`, nil, Constraints{}, []interface{}{"foo", 1.0}},
+			{"IntegerItems", `[1, "2"]`, &Field{Type: IntegerType}, Constraints{}, []interface{}{int64(1), int64(2)}},
+			{"NullItem", `[1, null]`, &Field{Type: IntegerType}, Constraints{}, []interface{}{int64(1), nil}},
+			// Items are cast like cells, so missing values become nil.
+			{"EmptyItem", `["a", ""]`, &Field{Type: StringType}, Constraints{}, []interface{}{"a", nil}},
+			{"MissingValueItem", `["a", "NA"]`, &Field{Type: StringType, MissingValues: map[string]struct{}{"NA": {}}}, Constraints{}, []interface{}{"a", nil}},
+			{"EmptyItemNotMissing", `["a", ""]`, &Field{Type: StringType, MissingValues: map[string]struct{}{}}, Constraints{}, []interface{}{"a", ""}},
+			{"ObjectItems", `[{"a":1}]`, &Field{Type: ObjectType}, Constraints{}, []interface{}{map[string]interface{}{"a": 1.0}}},
+			{"Length", `["a", "b"]`, nil, Constraints{MinLength: 2, MaxLength: 2}, []interface{}{"a", "b"}},
+		}
+		for _, d := range data {
+			t.Run(d.desc, func(t *testing.T) {
+				is := is.New(t)
+				got, err := castArray(d.value, d.item, d.c)
+				is.NoErr(err)
+				is.Equal(got, d.want)
+			})
+		}
+	})
+	t.Run("Error", func(t *testing.T) {
+		data := []struct {
+			desc  string
+			value string
+			item  *Field
+			c     Constraints
+		}{
+			{"NotAnArray", `{"a":1}`, nil, Constraints{}},
+			{"Null", `null`, nil, Constraints{}},
+			{"InvalidItem", `[1, "foo"]`, &Field{Type: IntegerType}, Constraints{}},
+			{"RequiredItem", `[1, null]`, &Field{Type: IntegerType, Constraints: Constraints{Required: true}}, Constraints{}},
+			{"RequiredEmptyItem", `["a", ""]`, &Field{Type: StringType, Constraints: Constraints{Required: true}}, Constraints{}},
+			{"ItemConstraint", `[1, 20]`, &Field{Type: IntegerType, Constraints: Constraints{Maximum: "10"}}, Constraints{}},
+			{"MinLength", `["a"]`, nil, Constraints{MinLength: 2}},
+			{"MaxLength", `["a", "b"]`, nil, Constraints{MaxLength: 1}},
+		}
+		for _, d := range data {
+			t.Run(d.desc, func(t *testing.T) {
+				is := is.New(t)
+				_, err := castArray(d.value, d.item, d.c)
+				is.True(err != nil)
+			})
+		}
+	})
+}
@@ -0,0 +1,385 @@
+package schema
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// FieldOpts defines functional options for creating a field.
+type FieldOpts func(f *Field) error
+
+// NewField creates a field with the passed-in name and type. Like fields read from
+// descriptors, it holds the default values described by the specification and its
+// constraints are compiled, so it is ready to cast values.
+//
+// Example:
+//
+//	f, err := NewField("id", IntegerType, Required(), Min(0))
+func NewField(name string, t FieldType, opts ...FieldOpts) (Field, error) {
+	f := withDefaults()
+	f.Name = name
+	f.Type = t
+	for _, opt := range opts {
+		if err := opt(&f); err != nil {
+			return Field{}, fmt.Errorf("invalid field %s: %v", name, err)
+		}
+	}
+	if err := f.compile(); err != nil {
+		return Field{}, fmt.Errorf("invalid field %s: %v", name, err)
+	}
+	return f, nil
+}
+
+// Title sets the human readable label of the field.
+func Title(title string) FieldOpts {
+	return func(f *Field) error {
+		f.Title = title
+		return nil
+	}
+}
+
+// Description sets the description of the field.
+func Description(desc string) FieldOpts {
+	return func(f *Field) error {
+		f.Description = desc
+		return nil
+	}
+}
+
+// Format sets the format of the field.
+func Format(format string) FieldOpts {
+	return func(f *Field) error {
+		f.Format = format
+		return nil
+	}
+}
+
+// TrueValues sets the values that represent true in boolean fields.
+func TrueValues(values ...string) FieldOpts {
+	return func(f *Field) error {
+		f.TrueValues = values
+		return nil
+	}
+}
+
+// FalseValues sets the values that represent false in boolean fields.
+func FalseValues(values ...string) FieldOpts {
+	return func(f *Field) error {
+		f.FalseValues = values
+		return nil
+	}
+}
+
+// DecimalChar sets the decimal point of number fields.
+func DecimalChar(c string) FieldOpts {
+	return func(f *Field) error {
+		f.DecimalChar = c
+		return nil
+	}
+}
+
+// GroupChar sets the character used to group digits of number fields.
+func GroupChar(c string) FieldOpts {
+	return func(f *Field) error {
+		f.GroupChar = c
+		return nil
+	}
+}
+
+// BareNumber sets whether number and integer values could contain leading and
+// trailing non-numeric characters.
+func BareNumber(bare bool) FieldOpts {
+	return func(f *Field) error {
+		f.BareNumber = bare
+		return nil
+	}
+}
+
+// UUIDVersions sets the UUID versions accepted by the "uuid" string format.
+func UUIDVersions(versions ...int) FieldOpts {
+	return func(f *Field) error {
+		f.UUIDVersions = versions
+		return nil
+	}
+}
+
+// ArrayItem sets the descriptor of the items of an array field. The item is built
+// like a field created by NewField, so its constraints are checked when the array
+// field is created.
+func ArrayItem(t FieldType, opts ...FieldOpts) FieldOpts {
+	return func(f *Field) error {
+		item, err := NewField("", t, opts...)
+		if err != nil {
+			return fmt.Errorf("invalid array item: %v", err)
+		}
+		f.ArrayItem = &item
+		return nil
+	}
+}
+
+// Property adds a property descriptor to an object field. Like ArrayItem, the
+// property is built like a field created by NewField.
+func Property(name string, t FieldType, opts ...FieldOpts) FieldOpts {
+	return func(f *Field) error {
+		p, err := NewField(name, t, opts...)
+		if err != nil {
+			return err
+		}
+		if f.Properties == nil {
+			f.Properties = make(map[string]Field)
+		}
+		f.Properties[name] = p
+		return nil
+	}
+}
+
+// Delimiter sets the delimiter of list fields.
+func Delimiter(d string) FieldOpts {
+	return func(f *Field) error {
+		f.Delimiter = d
+		return nil
+	}
+}
+
+// ItemType sets the type of the items of list fields.
+func ItemType(t FieldType) FieldOpts {
+	return func(f *Field) error {
+		f.ItemType = t
+		return nil
+	}
+}
+
+// Categories sets the categories of categorical fields. Values could either be a
+// Category, a string or an integer.
+func Categories(values ...interface{}) FieldOpts {
+	return func(f *Field) error {
+		for _, v := range values {
+			switch c := v.(type) {
+			case Category:
+				f.Categories = append(f.Categories, c)
+			case string:
+				f.Categories = append(f.Categories, Category{Value: c})
+			default:
+				rv := reflect.ValueOf(v)
+				switch rv.Kind() {
+				case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+					f.Categories = append(f.Categories, Category{Value: rv.Int()})
+				default:
+					return fmt.Errorf("invalid category %v: must be a string or an integer", v)
+				}
+			}
+		}
+		return nil
+	}
+}
+
+// CategoriesOrdered marks the order of the categories as meaningful.
+func CategoriesOrdered() FieldOpts {
+	return func(f *Field) error {
+		f.CategoriesOrdered = true
+		return nil
+	}
+}
+
+// MissingValues sets the values of the field which represent null values,
+// overriding the schema-level ones.
+func MissingValues(values ...string) FieldOpts {
+	return func(f *Field) error {
+		f.MissingValuesPlaceholder = values
+		f.MissingValues = make(map[string]struct{}, len(values))
+		for _, v := range values {
+			f.MissingValues[v] = struct{}{}
+		}
+		return nil
+	}
+}
+
+// Required sets the required constraint.
+func Required() FieldOpts {
+	return func(f *Field) error {
+		f.Constraints.Required = true
+		return nil
+	}
+}
+
+// Unique sets the unique constraint.
+func Unique() FieldOpts {
+	return func(f *Field) error {
+		f.Constraints.Unique = true
+		return nil
+	}
+}
+
+// Min sets the minimum constraint. See encodeBound for how v is encoded.
+func Min(v interface{}) FieldOpts {
+	return boundOpt(v, func(c *Constraints, b string) { c.Minimum = b })
+}
+
+// Max sets the maximum constraint. See encodeBound for how v is encoded.
+func Max(v interface{}) FieldOpts {
+	return boundOpt(v, func(c *Constraints, b string) { c.Maximum = b })
+}
+
+// ExclusiveMin sets the exclusiveMinimum constraint. See encodeBound for how v is encoded.
+func ExclusiveMin(v interface{}) FieldOpts {
+	return boundOpt(v, func(c *Constraints, b string) { c.ExclusiveMinimum = b })
+}
+
+// ExclusiveMax sets the exclusiveMaximum constraint. See encodeBound for how v is encoded.
+func ExclusiveMax(v interface{}) FieldOpts {
+	return boundOpt(v, func(c *Constraints, b string) { c.ExclusiveMaximum = b })
+}
+
+func boundOpt(v interface{}, set func(c *Constraints, b string)) FieldOpts {
+	return func(f *Field) error {
+		t := f.Type
+		if t == ListType {
+			t = f.listItem().Type
+		}
+		b, err := encodeBound(t, v)
+		if err != nil {
+			return err
+		}
+		set(&f.Constraints, b)
+		return nil
+	}
+}
+
+// encodeBound encodes a bound constraint of a field of type t. Strings are used
+// as they are, so they must be encoded like field values. Numbers, time.Duration
+// and time.Time values are encoded using the default format of the field type.
+func encodeBound(t FieldType, v interface{}) (string, error) {
+	switch b := v.(type) {
+	case string:
+		return b, nil
+	case time.Duration:
+		return uncastDuration(b)
+	case float64:
+		// Avoiding the exponent notation, which is not accepted by all number formats.
+		return strconv.FormatFloat(b, 'f', -1, 64), nil
+	case time.Time:
+		layouts := map[FieldType]string{
+			DateType:      "2006-01-02",
+			TimeType:      "15:04:05",
+			DateTimeType:  time.RFC3339,
+			YearMonthType: "2006-01",
+			YearType:      "2006",
+		}
+		l, ok := layouts[t]
+		if !ok {
+			return "", fmt.Errorf("invalid bound %v for type %s", v, t)
+		}
+		return b.In(time.UTC).Format(l), nil
+	}
+	switch reflect.ValueOf(v).Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return fmt.Sprintf("%v", v), nil
+	}
+	return "", fmt.Errorf("invalid bound %v for type %s", v, t)
+}
+
+// MinLength sets the minLength constraint.
+func MinLength(n int) FieldOpts {
+	return func(f *Field) error {
+		f.Constraints.MinLength = n
+		return nil
+	}
+}
+
+// MaxLength sets the maxLength constraint.
+func MaxLength(n int) FieldOpts {
+	return func(f *Field) error {
+		f.Constraints.MaxLength = n
+		return nil
+	}
+}
+
+// Pattern sets the pattern constraint.
+func Pattern(p string) FieldOpts {
+	return func(f *Field) error {
+		f.Constraints.Pattern = p
+		return nil
+	}
+}
+
+// Enum sets the enum constraint. Values are encoded like in schema descriptors.
+func Enum(values ...interface{}) FieldOpts {
+	return func(f *Field) error {
+		f.Constraints.Enum = values
+		return nil
+	}
+}
+
+// Builder builds schemas in code. Its methods can be chained and the first error
+// found is returned by Build.
+//
+// Example:
+//
+//	s, err := New().
+//	  Field("id", IntegerType, Required(), Min(0)).
+//	  Field("name", StringType, MaxLength(100)).
+//	  PrimaryKey("id").
+//	  Build()
+type Builder struct {
+	s   Schema
+	err error
+}
+
+// New creates a schema builder.
+func New() *Builder {
+	return &Builder{}
+}
+
+// Field appends a field to the schema. See NewField.
+func (b *Builder) Field(name string, t FieldType, opts ...FieldOpts) *Builder {
+	if b.err != nil {
+		return b
+	}
+	f, err := NewField(name, t, opts...)
+	if err != nil {
+		b.err = err
+		return b
+	}
+	b.s.Fields = append(b.s.Fields, f)
+	return b
+}
+
+// PrimaryKey sets the fields which compose the primary key of the schema.
+func (b *Builder) PrimaryKey(names ...string) *Builder {
+	b.s.PrimaryKeys = names
+	return b
+}
+
+// ForeignKey adds a foreign key to the schema. An empty resource references
+// the schema itself.
+func (b *Builder) ForeignKey(fields []string, resource string, referenceFields []string) *Builder {
+	b.s.ForeignKeys = append(b.s.ForeignKeys, ForeignKeys{
+		Fields:    fields,
+		Reference: ForeignKeyReference{Resource: resource, Fields: referenceFields},
+	})
+	return b
+}
+
+// MissingValues sets the schema-level values which represent null values.
+func (b *Builder) MissingValues(values ...string) *Builder {
+	b.s.MissingValues = values
+	return b
+}
+
+// Build validates and returns the schema.
+func (b *Builder) Build() (*Schema, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	s := b.s
+	s.Fields = append(Fields(nil), b.s.Fields...)
+	s.propagateMissingValues()
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
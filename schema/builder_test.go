@@ -0,0 +1,169 @@
+package schema
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestNewField(t *testing.T) {
+	t.Run("Defaults", func(t *testing.T) {
+		is := is.New(t)
+		f, err := NewField("n", NumberType)
+		is.NoErr(err)
+		is.Equal(f, asJSONField(Field{Name: "n", Type: NumberType}))
+		v, err := f.Cast("1,000.5")
+		is.NoErr(err)
+		is.Equal(v, 1000.5)
+	})
+	t.Run("LikeLoaded", func(t *testing.T) {
+		data := []struct {
+			Desc       string
+			Type       FieldType
+			Opts       []FieldOpts
+			Descriptor string
+		}{
+			{"Integer", IntegerType, []FieldOpts{Required(), Unique(), Min(0), ExclusiveMax(int8(10)), Enum(1, 2)},
+				`{"name":"f", "type":"integer", "constraints":{"required":true, "unique":true, "minimum":"0", "exclusiveMaximum":"10", "enum":[1, 2]}}`},
+			{"String", StringType, []FieldOpts{Format("email"), Title("t"), Description("d"), MinLength(1), MaxLength(10), Pattern(".*@a.com")},
+				`{"name":"f", "type":"string", "format":"email", "title":"t", "description":"d", "constraints":{"minLength":1, "maxLength":10, "pattern":".*@a.com"}}`},
+			{"Boolean", BooleanType, []FieldOpts{TrueValues("s"), FalseValues("n"), MissingValues("-")},
+				`{"name":"f", "type":"boolean", "trueValues":["s"], "falseValues":["n"], "missingValues":["-"]}`},
+			{"Number", NumberType, []FieldOpts{DecimalChar(","), GroupChar("."), BareNumber(false), Max(1.5)},
+				`{"name":"f", "type":"number", "decimalChar":",", "groupChar":".", "bareNumber":false, "constraints":{"maximum":"1.5"}}`},
+			{"Date", DateType, []FieldOpts{Min(time.Date(2015, 1, 2, 0, 0, 0, 0, time.UTC)), ExclusiveMin("2014-01-01")},
+				`{"name":"f", "type":"date", "constraints":{"minimum":"2015-01-02", "exclusiveMinimum":"2014-01-01"}}`},
+			{"Duration", DurationType, []FieldOpts{Max(24 * time.Hour)},
+				`{"name":"f", "type":"duration", "constraints":{"maximum":"P0Y0M1DT0S"}}`},
+			{"List", ListType, []FieldOpts{ItemType(IntegerType), Delimiter(";"), Max(10)},
+				`{"name":"f", "type":"list", "itemType":"integer", "delimiter":";", "constraints":{"maximum":"10"}}`},
+			{"Categorical", CategoricalType, []FieldOpts{Categories("a", 1, Category{Value: "b", Label: "B"}), CategoriesOrdered()},
+				`{"name":"f", "type":"categorical", "categories":["a", 1, {"value":"b", "label":"B"}], "categoriesOrdered":true}`},
+			{"Array", ArrayType, []FieldOpts{ArrayItem(IntegerType, Min(0))},
+				`{"name":"f", "type":"array", "arrayItem":{"type":"integer", "constraints":{"minimum":"0"}}}`},
+			{"UUID", StringType, []FieldOpts{Format("uuid"), UUIDVersions(4, 7)},
+				`{"name":"f", "type":"string", "format":"uuid", "uuidVersions":[4, 7]}`},
+		}
+		for _, d := range data {
+			t.Run(d.Desc, func(t *testing.T) {
+				is := is.New(t)
+				got, err := NewField("f", d.Type, d.Opts...)
+				is.NoErr(err)
+				var want Field
+				is.NoErr(want.UnmarshalJSON([]byte(d.Descriptor)))
+				// The placeholder keeps the type used to declare missing values.
+				got.MissingValuesPlaceholder, want.MissingValuesPlaceholder = nil, nil
+				// Enum values are normalized, so compiled versions must match.
+				got.Constraints.Enum, want.Constraints.Enum = nil, nil
+				if got.ArrayItem != nil {
+					got.ArrayItem.MissingValuesPlaceholder, want.ArrayItem.MissingValuesPlaceholder = nil, nil
+				}
+				is.True(reflect.DeepEqual(got, want))
+			})
+		}
+	})
+	t.Run("Object", func(t *testing.T) {
+		is := is.New(t)
+		f, err := NewField("o", ObjectType, Property("p", IntegerType, Required(), Enum(1, 2)))
+		is.NoErr(err)
+		is.Equal(f.Properties["p"].Name, "p")
+		_, err = f.Cast(`{}`)
+		is.True(err != nil)
+		_, err = f.Cast(`{"p":3}`)
+		is.True(err != nil)
+		_, err = f.Cast(`{"p":2}`)
+		is.NoErr(err)
+	})
+	t.Run("ArrayItem", func(t *testing.T) {
+		is := is.New(t)
+		f, err := NewField("a", ArrayType, ArrayItem(StringType, Enum("a", "b")))
+		is.NoErr(err)
+		_, err = f.Cast(`["a","b"]`)
+		is.NoErr(err)
+		_, err = f.Cast(`["c"]`)
+		is.True(err != nil)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		data := []struct {
+			Desc string
+			Type FieldType
+			Opts []FieldOpts
+		}{
+			{"Pattern", StringType, []FieldOpts{Pattern("(")}},
+			{"Enum", IntegerType, []FieldOpts{Enum("a")}},
+			{"Bound", IntegerType, []FieldOpts{Min(true)}},
+			{"TimeBound", IntegerType, []FieldOpts{Min(time.Now())}},
+			{"Category", CategoricalType, []FieldOpts{Categories(1.5)}},
+			{"ArrayItem", ArrayType, []FieldOpts{ArrayItem(IntegerType, Enum("a"))}},
+			{"Property", ObjectType, []FieldOpts{Property("p", StringType, Pattern("("))}},
+		}
+		for _, d := range data {
+			t.Run(d.Desc, func(t *testing.T) {
+				is := is.New(t)
+				_, err := NewField("f", d.Type, d.Opts...)
+				is.True(err != nil)
+			})
+		}
+	})
+}
+
+func TestBuilder(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		is := is.New(t)
+		s, err := New().
+			Field("id", IntegerType, Required(), Min(0)).
+			Field("name", StringType, MaxLength(5), MissingValues("")).
+			Field("parent", IntegerType).
+			PrimaryKey("id").
+			ForeignKey([]string{"parent"}, "", []string{"id"}).
+			MissingValues("NA").
+			Build()
+		is.NoErr(err)
+
+		want, err := Read(strings.NewReader(`{
+			"fields":[
+				{"name":"id", "type":"integer", "constraints":{"required":true, "minimum":"0"}},
+				{"name":"name", "type":"string", "missingValues":[""], "constraints":{"maxLength":5}},
+				{"name":"parent", "type":"integer"}
+			],
+			"primaryKey":"id",
+			"foreignKeys":[{"fields":"parent", "reference":{"resource":"", "fields":"id"}}],
+			"missingValues":["NA"]
+		}`))
+		is.NoErr(err)
+		for i := range want.Fields {
+			want.Fields[i].MissingValuesPlaceholder = nil
+			s.Fields[i].MissingValuesPlaceholder = nil
+		}
+		is.True(reflect.DeepEqual(s, want))
+
+		var row struct {
+			ID     int64  `tableheader:"id"`
+			Name   string `tableheader:"name"`
+			Parent *int64 `tableheader:"parent"`
+		}
+		is.NoErr(s.CastRow([]string{"1", "", "NA"}, &row))
+		is.Equal(row.ID, int64(1))
+		is.True(row.Parent == nil)
+		is.True(s.CastRow([]string{"-1", "", "NA"}, &row) != nil)
+		is.True(s.CastRow([]string{"NA", "", "NA"}, &row) != nil)
+
+		buf := bytes.NewBufferString("")
+		is.NoErr(s.Write(buf))
+		is.True(strings.Contains(buf.String(), `"minimum": "0"`))
+	})
+	t.Run("InvalidField", func(t *testing.T) {
+		is := is.New(t)
+		_, err := New().Field("a", StringType, Pattern("(")).Field("b", StringType).Build()
+		is.True(err != nil)
+	})
+	t.Run("InvalidSchema", func(t *testing.T) {
+		is := is.New(t)
+		_, err := New().Field("a", "integr").PrimaryKey("b").Build()
+		is.Equal(pointers(err), []string{"/fields/0/type", "/primaryKey/0"})
+	})
+}
@@ -0,0 +1,131 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Category represents a single category of a "categorical" field. Its value
+// could either be a string or an integer.
+// More at: https://datapackage.org/standard/table-schema/#categorical
+type Category struct {
+	Value interface{} `json:"value"`
+	Label string      `json:"label,omitempty"`
+}
+
+// UnmarshalJSON sets *c to a copy of data. Categories can be described as a bare
+// value or as a {"value", "label"} object. Integer values are decoded as int64.
+func (c *Category) UnmarshalJSON(data []byte) error {
+	type categoryAlias struct {
+		Value json.RawMessage `json:"value"`
+		Label string          `json:"label,omitempty"`
+	}
+	raw := json.RawMessage(data)
+	var a categoryAlias
+	if len(data) > 0 && data[0] == '{' {
+		if err := json.Unmarshal(data, &a); err != nil {
+			return err
+		}
+		raw = a.Value
+	}
+	v, err := decodeCategoryValue(raw)
+	if err != nil {
+		return fmt.Errorf("invalid category %s: %v", string(data), err)
+	}
+	c.Value = v
+	c.Label = a.Label
+	return nil
+}
+
+// MarshalJSON returns the JSON encoding of c. Categories without labels are
+// encoded as bare values.
+func (c Category) MarshalJSON() ([]byte, error) {
+	if c.Label == "" {
+		return json.Marshal(c.Value)
+	}
+	type categoryAlias Category
+	return json.Marshal(categoryAlias(c))
+}
+
+func decodeCategoryValue(raw json.RawMessage) (interface{}, error) {
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s, nil
+	}
+	var i int64
+	if err := json.Unmarshal(raw, &i); err == nil {
+		return i, nil
+	}
+	return nil, fmt.Errorf("category value must be a string or an integer")
+}
+
+// raw returns the physical representation of the category value.
+func (c Category) raw() string {
+	return fmt.Sprintf("%v", c.Value)
+}
+
+// category returns the index of the category whose physical representation
+// matches the passed-in value.
+func (f *Field) category(raw string) (int, bool) {
+	for i := range f.Categories {
+		if f.Categories[i].raw() == raw {
+			return i, true
+		}
+	}
+	return InvalidPosition, false
+}
+
+func castCategorical(f *Field, value string) (interface{}, error) {
+	i, ok := f.category(value)
+	if !ok {
+		return nil, fmt.Errorf("invalid category:\"%s\" for field %s", value, f.Name)
+	}
+	return f.Categories[i].Value, nil
+}
+
+func uncastCategorical(f *Field, in interface{}) (string, error) {
+	raw := fmt.Sprintf("%v", in)
+	if _, ok := f.category(raw); !ok {
+		return "", fmt.Errorf("invalid category:\"%v\" for field %s", in, f.Name)
+	}
+	return raw, nil
+}
+
+// CategoryLabel returns the label of the category which value is the passed-in
+// (cast) value. If the category has no label, its value is returned as label.
+// It returns false if the value is not a category of the field.
+func (f *Field) CategoryLabel(value interface{}) (string, bool) {
+	i, ok := f.category(fmt.Sprintf("%v", value))
+	if !ok {
+		return "", false
+	}
+	if f.Categories[i].Label == "" {
+		return f.Categories[i].raw(), true
+	}
+	return f.Categories[i].Label, true
+}
+
+// CompareCategories compares two (cast) values of an ordered categorical field,
+// following the order categories were declared. The result will be 0 if a == b,
+// -1 if a < b, and +1 if a > b. An error is returned if the field categories are
+// not ordered or any of the values is not a category of the field.
+func (f *Field) CompareCategories(a, b interface{}) (int, error) {
+	if !f.CategoriesOrdered {
+		return 0, fmt.Errorf("categories of field %s are not ordered", f.Name)
+	}
+	i, ok := f.category(fmt.Sprintf("%v", a))
+	if !ok {
+		return 0, fmt.Errorf("invalid category:\"%v\" for field %s", a, f.Name)
+	}
+	j, ok := f.category(fmt.Sprintf("%v", b))
+	if !ok {
+		return 0, fmt.Errorf("invalid category:\"%v\" for field %s", b, f.Name)
+	}
+	switch {
+	case i < j:
+		return -1, nil
+	case i > j:
+		return 1, nil
+	}
+	return 0, nil
+}
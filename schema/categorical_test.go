@@ -0,0 +1,93 @@
+package schema
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestCategory_JSON(t *testing.T) {
+	is := is.New(t)
+	var f Field
+	is.NoErr(json.Unmarshal([]byte(`{"name":"c","type":"categorical","categories":["a",{"value":1,"label":"One"}],"categoriesOrdered":true}`), &f))
+	is.Equal(f.Categories, []Category{{Value: "a"}, {Value: int64(1), Label: "One"}})
+	is.True(f.CategoriesOrdered)
+
+	b, err := json.Marshal(f.Categories)
+	is.NoErr(err)
+	is.Equal(string(b), `["a",{"value":1,"label":"One"}]`)
+
+	t.Run("Error", func(t *testing.T) {
+		is := is.New(t)
+		var c Category
+		is.True(json.Unmarshal([]byte(`1.5`), &c) != nil)
+		is.True(json.Unmarshal([]byte(`{"value":true}`), &c) != nil)
+	})
+}
+
+func TestCastCategorical(t *testing.T) {
+	f := Field{Name: "c", Type: CategoricalType, Categories: []Category{{Value: "low", Label: "Low"}, {Value: "high"}, {Value: int64(3)}}}
+	t.Run("Success", func(t *testing.T) {
+		data := []struct {
+			desc  string
+			value string
+			want  interface{}
+		}{
+			{"String", "low", "low"},
+			{"Integer", "3", int64(3)},
+		}
+		for _, d := range data {
+			t.Run(d.desc, func(t *testing.T) {
+				is := is.New(t)
+				got, err := f.Cast(d.value)
+				is.NoErr(err)
+				is.Equal(got, d.want)
+			})
+		}
+	})
+	t.Run("Error", func(t *testing.T) {
+		is := is.New(t)
+		_, err := f.Cast("Low")
+		is.True(err != nil)
+	})
+	t.Run("Uncast", func(t *testing.T) {
+		is := is.New(t)
+		got, err := f.Uncast(3)
+		is.NoErr(err)
+		is.Equal(got, "3")
+		_, err = f.Uncast("medium")
+		is.True(err != nil)
+	})
+	t.Run("Label", func(t *testing.T) {
+		is := is.New(t)
+		l, ok := f.CategoryLabel("low")
+		is.True(ok)
+		is.Equal(l, "Low")
+		l, ok = f.CategoryLabel("high")
+		is.True(ok)
+		is.Equal(l, "high")
+		_, ok = f.CategoryLabel("medium")
+		is.True(!ok)
+	})
+}
+
+func TestCompareCategories(t *testing.T) {
+	is := is.New(t)
+	f := Field{Name: "c", Type: CategoricalType, Categories: []Category{{Value: "low"}, {Value: "medium"}, {Value: "high"}}}
+	_, err := f.CompareCategories("low", "high")
+	is.True(err != nil) // categories are not ordered.
+
+	f.CategoriesOrdered = true
+	c, err := f.CompareCategories("low", "high")
+	is.NoErr(err)
+	is.Equal(c, -1)
+	c, err = f.CompareCategories("high", "medium")
+	is.NoErr(err)
+	is.Equal(c, 1)
+	c, err = f.CompareCategories("medium", "medium")
+	is.NoErr(err)
+	is.Equal(c, 0)
+	_, err = f.CompareCategories("low", "foo")
+	is.True(err != nil)
+}
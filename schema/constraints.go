@@ -0,0 +1,240 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Names of the constraints, as used in schema descriptors.
+const (
+	requiredConstraint         = "required"
+	uniqueConstraint           = "unique"
+	minimumConstraint          = "minimum"
+	maximumConstraint          = "maximum"
+	exclusiveMinimumConstraint = "exclusiveMinimum"
+	exclusiveMaximumConstraint = "exclusiveMaximum"
+	minLengthConstraint        = "minLength"
+	maxLengthConstraint        = "maxLength"
+	patternConstraint          = "pattern"
+	enumConstraint             = "enum"
+)
+
+// Field types each constraint applies to. Constraints not listed here
+// (required, unique and enum) apply to all types.
+// More at: https://specs.frictionlessdata.io/table-schema/#constraints
+var (
+	boundedTypes = []FieldType{IntegerType, NumberType, DateType, TimeType, DateTimeType, YearType, YearMonthType, DurationType, ListType}
+	lengthTypes  = []FieldType{StringType, ArrayType, ObjectType, ListType}
+
+	constraintTypes = map[string][]FieldType{
+		minimumConstraint:          boundedTypes,
+		maximumConstraint:          boundedTypes,
+		exclusiveMinimumConstraint: boundedTypes,
+		exclusiveMaximumConstraint: boundedTypes,
+		minLengthConstraint:        lengthTypes,
+		maxLengthConstraint:        lengthTypes,
+		patternConstraint:          []FieldType{StringType, ListType},
+	}
+)
+
+// UnmarshalJSON sets *c to a copy of data. Bounds can be JSON strings or numbers, as
+// allowed by the specification; numbers are kept as written.
+func (c *Constraints) UnmarshalJSON(data []byte) error {
+	// This is needed so it does not call UnmarshalJSON recursively.
+	type constraintsAlias Constraints
+	var u struct {
+		constraintsAlias
+		Maximum          boundValue `json:"maximum"`
+		Minimum          boundValue `json:"minimum"`
+		ExclusiveMaximum boundValue `json:"exclusiveMaximum"`
+		ExclusiveMinimum boundValue `json:"exclusiveMinimum"`
+	}
+	if err := json.Unmarshal(data, &u); err != nil {
+		return err
+	}
+	*c = Constraints(u.constraintsAlias)
+	c.Maximum, c.Minimum = string(u.Maximum), string(u.Minimum)
+	c.ExclusiveMaximum, c.ExclusiveMinimum = string(u.ExclusiveMaximum), string(u.ExclusiveMinimum)
+	return nil
+}
+
+// boundValue is a bound constraint, which is decoded from JSON strings or numbers.
+type boundValue string
+
+func (b *boundValue) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*b = boundValue(s)
+		return nil
+	}
+	var n json.Number
+	if err := json.Unmarshal(data, &n); err != nil {
+		return fmt.Errorf("bound must be a string or a number, got:%s", data)
+	}
+	*b = boundValue(n)
+	return nil
+}
+
+// set returns the names of the constraints which are set.
+func (c Constraints) set() []string {
+	var ret []string
+	for _, v := range []struct {
+		name string
+		set  bool
+	}{
+		{requiredConstraint, c.Required},
+		{uniqueConstraint, c.Unique},
+		{minimumConstraint, c.Minimum != ""},
+		{maximumConstraint, c.Maximum != ""},
+		{exclusiveMinimumConstraint, c.ExclusiveMinimum != ""},
+		{exclusiveMaximumConstraint, c.ExclusiveMaximum != ""},
+		{minLengthConstraint, c.MinLength != 0},
+		{maxLengthConstraint, c.MaxLength != 0},
+		{patternConstraint, c.Pattern != ""},
+		{enumConstraint, len(c.Enum) > 0},
+	} {
+		if v.set {
+			ret = append(ret, v.name)
+		}
+	}
+	return ret
+}
+
+// inapplicableConstraints returns the names of the constraints set in the field
+// which do not apply to its type. Unknown and custom types are not checked.
+func inapplicableConstraints(f *Field) []string {
+	if _, ok := fieldTypes[f.Type]; !ok {
+		return nil
+	}
+	var ret []string
+	for _, name := range f.Constraints.set() {
+		types, ok := constraintTypes[name]
+		if !ok {
+			continue
+		}
+		applicable := false
+		for _, t := range types {
+			if t == f.Type {
+				applicable = true
+				break
+			}
+		}
+		if !applicable {
+			ret = append(ret, name)
+		}
+	}
+	return ret
+}
+
+// checkBounds checks the minimum, maximum, exclusiveMinimum and exclusiveMaximum constraints.
+// The cmp function parses the constraint value and compares it to v, returning a negative
+// number if v is smaller than the bound, zero if they are equal and a positive number otherwise.
+func checkBounds(t FieldType, v interface{}, c Constraints, cmp func(bound string) (int, error)) error {
+	for _, b := range []struct {
+		name  string
+		bound string
+		op    string
+		fails func(int) bool
+	}{
+		{maximumConstraint, c.Maximum, ">", func(r int) bool { return r > 0 }},
+		{minimumConstraint, c.Minimum, "<", func(r int) bool { return r < 0 }},
+		{exclusiveMaximumConstraint, c.ExclusiveMaximum, ">=", func(r int) bool { return r >= 0 }},
+		{exclusiveMinimumConstraint, c.ExclusiveMinimum, "<=", func(r int) bool { return r <= 0 }},
+	} {
+		if b.bound == "" {
+			continue
+		}
+		r, err := cmp(b.bound)
+		if err != nil {
+			return fmt.Errorf("invalid %s %s: %v", b.name, t, b.bound)
+		}
+		if b.fails(r) {
+			return fmt.Errorf("constraint check error: %s:%v %s %s:%v", t, v, b.op, b.name, b.bound)
+		}
+	}
+	return nil
+}
+
+// boundCaster returns the field used to cast bound constraints, which are encoded
+// like field values (list item values for list fields). It has no constraints nor
+// missing values.
+func (f *Field) boundCaster() Field {
+	caster := *f
+	if f.Type == ListType {
+		caster = f.listItem()
+	}
+	caster.Constraints = Constraints{}
+	caster.MissingValues = map[string]struct{}{}
+	return caster
+}
+
+// checkTimeConstraints checks the bound constraints of temporal types. Bounds are parsed
+// using the passed-in function.
+func checkTimeConstraints(v time.Time, c Constraints, t FieldType, parse func(string) (time.Time, error)) (time.Time, error) {
+	err := checkBounds(t, v, c, func(bound string) (int, error) {
+		b, err := parse(bound)
+		if err != nil {
+			return 0, err
+		}
+		switch {
+		case v.Before(b):
+			return -1, nil
+		case v.After(b):
+			return 1, nil
+		}
+		return 0, nil
+	})
+	return v, err
+}
+
+// compileEnum returns the set of raw enum values. Enum values are cast to the field
+// type and uncast back, so cells are compared to them using their typed values. For
+// instance, 1 and "1.0" are the same enum value for number fields.
+func (f *Field) compileEnum() (map[string]struct{}, error) {
+	// Enum applies to list items, not to the list as a whole.
+	caster := *f
+	if f.Type == ListType {
+		caster = f.listItem()
+	}
+	caster.Constraints.rawEnum = nil
+	rawEnum := make(map[string]struct{}, len(f.Constraints.Enum))
+	for _, e := range f.Constraints.Enum {
+		raw, err := caster.normalizeEnumValue(e)
+		if err != nil {
+			return nil, fmt.Errorf("invalid enum value %v: %v", e, err)
+		}
+		rawEnum[raw] = struct{}{}
+	}
+	return rawEnum, nil
+}
+
+func (f *Field) normalizeEnumValue(e interface{}) (string, error) {
+	raw, ok := e.(string)
+	if !ok {
+		b, err := json.Marshal(e)
+		if err != nil {
+			return "", err
+		}
+		raw = string(b)
+	}
+	castd, err := f.Cast(raw)
+	if err == nil && castd != nil {
+		if u, err := f.enumKey(castd); err == nil {
+			return u, nil
+		}
+	}
+	// Values that can not be cast from their JSON representation (for instance,
+	// booleans when trueValues are customized) are compared using their uncast version.
+	return f.enumKey(e)
+}
+
+// enumKey returns the string cast values are compared to enum values by. Arrays and
+// objects use their JSON encoding, as their uncast version is ambiguous: ["a b"] and
+// ["a","b"] would be the same enum value.
+func (f *Field) enumKey(v interface{}) (string, error) {
+	if f.Type == ArrayType || f.Type == ObjectType {
+		return uncastObject(v)
+	}
+	return f.Uncast(v)
+}
@@ -0,0 +1,172 @@
+package schema
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestField_CastConstraints(t *testing.T) {
+	data := []struct {
+		desc  string
+		field string
+		ok    []string
+		fail  []string
+	}{
+		{
+			"IntegerExclusive",
+			`{"name":"n","type":"integer","constraints":{"exclusiveMinimum":"1","exclusiveMaximum":"3"}}`,
+			[]string{"2"},
+			[]string{"1", "3"},
+		},
+		{
+			"NumberExclusive",
+			`{"name":"n","type":"number","constraints":{"exclusiveMinimum":"1.5","maximum":"3"}}`,
+			[]string{"1.6", "3"},
+			[]string{"1.5", "3.1"},
+		},
+		{
+			"DateExclusive",
+			`{"name":"n","type":"date","constraints":{"exclusiveMaximum":"2020-01-02"}}`,
+			[]string{"2020-01-01"},
+			[]string{"2020-01-02"},
+		},
+		{
+			"TimeMinimum",
+			`{"name":"n","type":"time","constraints":{"minimum":"00:00:00"}}`,
+			[]string{"00:00:00", "10:10:10"},
+			[]string{},
+		},
+		{
+			"Duration",
+			`{"name":"n","type":"duration","constraints":{"minimum":"PT1H","exclusiveMaximum":"P1D"}}`,
+			[]string{"PT1H", "PT23H"},
+			[]string{"PT59M", "P1D"},
+		},
+		{
+			"StringLengthInCharacters",
+			`{"name":"n","type":"string","constraints":{"minLength":3,"maxLength":3}}`,
+			[]string{"ção", "abc"},
+			[]string{"ab", "abcd"},
+		},
+		{
+			"ObjectLength",
+			`{"name":"n","type":"object","constraints":{"minLength":1,"maxLength":2}}`,
+			[]string{`{"a":1}`, `{"a":1,"b":2}`},
+			[]string{`{}`, `{"a":1,"b":2,"c":3}`},
+		},
+		{
+			"NumberEnum",
+			`{"name":"n","type":"number","constraints":{"enum":[1, "2.5"]}}`,
+			[]string{"1", "1.0", "2.50"},
+			[]string{"2"},
+		},
+		{
+			"DateEnum",
+			`{"name":"n","type":"date","constraints":{"enum":["2020-01-01"]}}`,
+			[]string{"2020-01-01"},
+			[]string{"2020-01-02"},
+		},
+		{
+			"BooleanEnum",
+			`{"name":"n","type":"boolean","constraints":{"enum":[true]}}`,
+			[]string{"true", "yes", "1"},
+			[]string{"false"},
+		},
+		{
+			"GeoPointEnum",
+			`{"name":"n","type":"geopoint","constraints":{"enum":["10,10"]}}`,
+			[]string{"10,10", "10.0, 10"},
+			[]string{"10,11"},
+		},
+		{
+			"DurationEnum",
+			`{"name":"n","type":"duration","constraints":{"enum":["PT1H"]}}`,
+			[]string{"PT60M"},
+			[]string{"PT2H"},
+		},
+		{
+			"ListExclusive",
+			`{"name":"n","type":"list","itemType":"integer","constraints":{"exclusiveMinimum":"0","exclusiveMaximum":"5"}}`,
+			[]string{"1,4", "2"},
+			[]string{"1,5,7", "0,1"},
+		},
+		{
+			"ListBounds",
+			`{"name":"n","type":"list","itemType":"number","constraints":{"minimum":"0","maximum":"5"}}`,
+			[]string{"0,5", "2.5"},
+			[]string{"1,5.5", "-1"},
+		},
+		{
+			"ArrayEnum",
+			`{"name":"n","type":"array","constraints":{"enum":[["a","b"]]}}`,
+			[]string{`["a","b"]`, `[ "a", "b" ]`},
+			[]string{`["a b"]`, `["b","a"]`},
+		},
+		{
+			"ObjectEnum",
+			`{"name":"n","type":"object","constraints":{"enum":[{"a":"b c"}]}}`,
+			[]string{`{"a":"b c"}`},
+			[]string{`{"a":["b","c"]}`},
+		},
+	}
+	for _, d := range data {
+		t.Run(d.desc, func(t *testing.T) {
+			is := is.New(t)
+			var f Field
+			is.NoErr(json.Unmarshal([]byte(d.field), &f))
+			for _, v := range d.ok {
+				_, err := f.Cast(v)
+				is.NoErr(err)
+			}
+			for _, v := range d.fail {
+				_, err := f.Cast(v)
+				is.True(err != nil)
+			}
+		})
+	}
+	t.Run("InvalidBound", func(t *testing.T) {
+		is := is.New(t)
+		f := Field{Name: "n", Type: IntegerType, Constraints: Constraints{Maximum: "abc"}}
+		_, err := f.Cast("1")
+		is.True(err != nil)
+	})
+}
+
+func TestInapplicableConstraints(t *testing.T) {
+	t.Run("Applicable", func(t *testing.T) {
+		data := []Field{
+			{Type: IntegerType, Constraints: Constraints{Minimum: "1", ExclusiveMaximum: "2", Required: true, Unique: true}},
+			{Type: DurationType, Constraints: Constraints{Maximum: "P1D"}},
+			{Type: StringType, Constraints: Constraints{MinLength: 1, Pattern: ".*"}},
+			{Type: ArrayType, Constraints: Constraints{MaxLength: 1}},
+			{Type: ObjectType, Constraints: Constraints{MaxLength: 1}},
+			{Type: ListType, Constraints: Constraints{ExclusiveMinimum: "0", ExclusiveMaximum: "5"}},
+			{Type: BooleanType, Constraints: Constraints{Enum: []interface{}{true}}},
+			{Type: "custom", Constraints: Constraints{Maximum: "1"}},
+		}
+		for _, f := range data {
+			is := is.New(t)
+			is.Equal(len(inapplicableConstraints(&f)), 0)
+		}
+	})
+	t.Run("NotApplicable", func(t *testing.T) {
+		data := []Field{
+			{Type: BooleanType, Constraints: Constraints{Minimum: "1"}},
+			{Type: GeoPointType, Constraints: Constraints{ExclusiveMaximum: "1"}},
+			{Type: IntegerType, Constraints: Constraints{MinLength: 1}},
+			{Type: ArrayType, Constraints: Constraints{Pattern: ".*"}},
+			{Type: DateType, Constraints: Constraints{MaxLength: 1}},
+		}
+		for _, f := range data {
+			is := is.New(t)
+			is.True(len(inapplicableConstraints(&f)) > 0)
+		}
+	})
+	t.Run("Validate", func(t *testing.T) {
+		is := is.New(t)
+		s := Schema{Fields: []Field{{Name: "b", Type: BooleanType, Constraints: Constraints{Maximum: "1"}}}}
+		is.True(s.Validate() != nil)
+	})
+}
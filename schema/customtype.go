@@ -0,0 +1,154 @@
+package schema
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Caster casts the values of a custom field type, registered using RegisterType. The
+// field passed to its methods holds the descriptor properties, like Format and
+// Constraints, which are interpreted by the caster.
+//
+// Schema.CastRow sets the cast values to the struct fields of types they are
+// convertible to. As nested structs are flattened, values which are structs must be
+// set to interface{} fields.
+type Caster interface {
+	// Cast casts the passed-in string to a value of the type. Missing values are
+	// handled by Field.Cast and never passed to the caster.
+	Cast(f *Field, value string) (interface{}, error)
+	// Uncast encodes a value of the type, like the ones returned by Cast.
+	Uncast(f *Field, v interface{}) (string, error)
+	// CheckConstraints checks the value returned by Cast against the field constraints.
+	// The required and enum constraints are checked by Field.Cast for all types.
+	CheckConstraints(f *Field, v interface{}) error
+}
+
+// Detector can be implemented by the Caster of a custom type, to make the type
+// inferable.
+type Detector interface {
+	// Detect returns true if the value is of the type.
+	Detect(value string) bool
+}
+
+// TypeOpts is the type of the optional arguments of RegisterType.
+type TypeOpts func(*customType) error
+
+// InferBefore makes inference try the custom type right before the built-in type t.
+// For instance, InferBefore(IntegerType) lets a postal code type win over integer.
+// The caster must implement Detector.
+func InferBefore(t FieldType) TypeOpts {
+	return func(c *customType) error {
+		if _, ok := c.caster.(Detector); !ok {
+			return fmt.Errorf("the caster must implement Detector to be inferred before %s", t)
+		}
+		for _, o := range orderedTypes {
+			if o == t {
+				c.before = t
+				return nil
+			}
+		}
+		return fmt.Errorf("type %s is not inferred", t)
+	}
+}
+
+// customType is a registered type.
+type customType struct {
+	caster Caster
+	// before is the built-in type the custom type is inferred before, if any.
+	before FieldType
+}
+
+var (
+	customTypesMu sync.RWMutex
+	customTypes   = make(map[FieldType]customType)
+	// detectableTypes holds the custom types implementing Detector, in registration order.
+	detectableTypes []FieldType
+)
+
+// RegisterType registers a custom field type, so fields of the type are cast, uncast and
+// checked by the caster. Descriptors declaring the type are valid: any format is
+// accepted and constraints are not checked for applicability, as they are interpreted
+// by the caster. Bound and enum constraints must be values the caster is able to cast.
+//
+// If the caster implements Detector, the type is also inferred. Custom types are tried
+// after the built-in ones, in registration order, unless positioned using InferBefore.
+// WithPriorityOrder overrides the order of all types, custom ones included.
+//
+// Types are usually registered by init functions. RegisterType returns an error if the
+// type is already registered or is a built-in type.
+func RegisterType(name FieldType, c Caster, opts ...TypeOpts) error {
+	if name == "" || c == nil {
+		return fmt.Errorf("custom types must have a name and a caster")
+	}
+	ct := customType{caster: c}
+	for _, opt := range opts {
+		if err := opt(&ct); err != nil {
+			return err
+		}
+	}
+	customTypesMu.Lock()
+	defer customTypesMu.Unlock()
+	if _, ok := fieldTypes[name]; ok {
+		return fmt.Errorf("type %s is a built-in type", name)
+	}
+	if _, ok := customTypes[name]; ok {
+		return fmt.Errorf("type %s is already registered", name)
+	}
+	customTypes[name] = ct
+	if _, ok := c.(Detector); ok {
+		detectableTypes = append(detectableTypes, name)
+	}
+	return nil
+}
+
+// customCaster returns the caster of a registered type.
+func customCaster(t FieldType) (Caster, bool) {
+	customTypesMu.RLock()
+	defer customTypesMu.RUnlock()
+	c, ok := customTypes[t]
+	return c.caster, ok
+}
+
+// detectCustomType returns true if the custom type detects the value.
+func detectCustomType(t FieldType, value string) bool {
+	c, ok := customCaster(t)
+	if !ok {
+		return false
+	}
+	d, ok := c.(Detector)
+	return ok && d.Detect(value)
+}
+
+// inferableTypes returns the types tried by inference, which are the built-in types
+// ordered from narrower to wider along with the detectable custom types. Custom types
+// are placed right before the type passed to InferBefore, or at the end.
+func inferableTypes() []FieldType {
+	customTypesMu.RLock()
+	defer customTypesMu.RUnlock()
+	ret := make([]FieldType, 0, len(orderedTypes)+len(detectableTypes))
+	addBefore := func(t FieldType) {
+		for _, ct := range detectableTypes {
+			if customTypes[ct].before == t {
+				ret = append(ret, ct)
+			}
+		}
+	}
+	for _, t := range orderedTypes {
+		addBefore(t)
+		ret = append(ret, t)
+	}
+	addBefore("")
+	return ret
+}
+
+// castCustom casts the value using the caster of a custom type.
+func castCustom(c Caster, f *Field, value string) (interface{}, error) {
+	v, err := c.Cast(f, value)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.CheckConstraints(f, v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
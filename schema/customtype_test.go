@@ -0,0 +1,236 @@
+package schema
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+
+	"github.com/frictionlessdata/tableschema-go/table"
+)
+
+const (
+	moneyType   FieldType = "money"
+	zipCodeType FieldType = "zipcode"
+	accountType FieldType = "account"
+)
+
+type money struct {
+	Amount   float64
+	Currency string
+}
+
+var moneyRegexp = regexp.MustCompile(`^-?\d+(\.\d+)? [A-Z]{3}$`)
+
+// moneyCaster casts values like "12.50 EUR".
+type moneyCaster struct{}
+
+func (moneyCaster) Cast(f *Field, value string) (interface{}, error) {
+	if !moneyRegexp.MatchString(value) {
+		return nil, fmt.Errorf("invalid money:%q", value)
+	}
+	parts := strings.Split(value, " ")
+	amount, err := strconv.ParseFloat(parts[0], 64)
+	if err != nil {
+		return nil, err
+	}
+	return money{amount, parts[1]}, nil
+}
+
+func (moneyCaster) Uncast(f *Field, v interface{}) (string, error) {
+	m, ok := v.(money)
+	if !ok {
+		return "", fmt.Errorf("invalid money:%v", v)
+	}
+	return fmt.Sprintf("%s %s", strconv.FormatFloat(m.Amount, 'f', -1, 64), m.Currency), nil
+}
+
+func (c moneyCaster) CheckConstraints(f *Field, v interface{}) error {
+	if f.Constraints.Minimum == "" {
+		return nil
+	}
+	min, err := c.Cast(f, f.Constraints.Minimum)
+	if err != nil {
+		return err
+	}
+	if v.(money).Amount < min.(money).Amount {
+		return fmt.Errorf("%v is less than the minimum:%v", v, min)
+	}
+	return nil
+}
+
+func (moneyCaster) Detect(value string) bool {
+	return moneyRegexp.MatchString(value)
+}
+
+// zipCodeCaster casts 5-digit codes, which are also valid integers.
+type zipCodeCaster struct{}
+
+func (zipCodeCaster) Cast(f *Field, value string) (interface{}, error) {
+	if len(value) != 5 {
+		return nil, fmt.Errorf("invalid zip code:%q", value)
+	}
+	if _, err := strconv.Atoi(value); err != nil {
+		return nil, fmt.Errorf("invalid zip code:%q", value)
+	}
+	return value, nil
+}
+
+func (zipCodeCaster) Uncast(f *Field, v interface{}) (string, error) {
+	return fmt.Sprint(v), nil
+}
+
+func (zipCodeCaster) CheckConstraints(f *Field, v interface{}) error { return nil }
+
+func (c zipCodeCaster) Detect(value string) bool {
+	_, err := c.Cast(nil, value)
+	return err == nil
+}
+
+// accountCaster casts 9-digit account numbers starting with 99, which are also valid
+// integers. It is registered to be inferred before integer.
+type accountCaster struct{ zipCodeCaster }
+
+var accountRegexp = regexp.MustCompile(`^99\d{7}$`)
+
+func (accountCaster) Cast(f *Field, value string) (interface{}, error) {
+	if !accountRegexp.MatchString(value) {
+		return nil, fmt.Errorf("invalid account:%q", value)
+	}
+	return value, nil
+}
+
+func (accountCaster) Detect(value string) bool {
+	return accountRegexp.MatchString(value)
+}
+
+func init() {
+	if err := RegisterType(moneyType, moneyCaster{}); err != nil {
+		panic(err)
+	}
+	if err := RegisterType(zipCodeType, zipCodeCaster{}); err != nil {
+		panic(err)
+	}
+	if err := RegisterType(accountType, accountCaster{}, InferBefore(IntegerType)); err != nil {
+		panic(err)
+	}
+}
+
+func TestRegisterType(t *testing.T) {
+	is := is.New(t)
+	is.True(RegisterType(IntegerType, moneyCaster{}) != nil)
+	is.True(RegisterType(moneyType, moneyCaster{}) != nil)
+	is.True(RegisterType("foo", nil) != nil)
+	// Only detectable types can be positioned, before inferred types.
+	is.True(RegisterType("foo", badCaster{}, InferBefore(IntegerType)) != nil)
+	is.True(RegisterType("foo", moneyCaster{}, InferBefore(StringType)) != nil)
+}
+
+// badCaster does not implement Detector.
+type badCaster struct{}
+
+func (badCaster) Cast(f *Field, value string) (interface{}, error) { return value, nil }
+func (badCaster) Uncast(f *Field, v interface{}) (string, error)   { return fmt.Sprint(v), nil }
+func (badCaster) CheckConstraints(f *Field, v interface{}) error   { return nil }
+
+func TestCustomType_Cast(t *testing.T) {
+	f := Field{Name: "price", Type: moneyType, Constraints: Constraints{Minimum: "0 EUR"}}
+	t.Run("Cast", func(t *testing.T) {
+		is := is.New(t)
+		v, err := f.Cast("12.50 EUR")
+		is.NoErr(err)
+		is.Equal(v, money{12.5, "EUR"})
+		s, err := f.Uncast(v)
+		is.NoErr(err)
+		is.Equal(s, "12.5 EUR")
+	})
+	t.Run("Errors", func(t *testing.T) {
+		is := is.New(t)
+		_, err := f.Cast("12.50")
+		is.True(err != nil)
+		_, err = f.Cast("-1 EUR")
+		is.True(err != nil)
+	})
+	t.Run("Enum", func(t *testing.T) {
+		is := is.New(t)
+		f := Field{Name: "price", Type: moneyType, Constraints: Constraints{Enum: []interface{}{"1.0 EUR", "2 EUR"}}}
+		is.NoErr(f.compile())
+		_, err := f.Cast("1 EUR")
+		is.NoErr(err)
+		_, err = f.Cast("3 EUR")
+		is.True(err != nil)
+	})
+	t.Run("CastRow", func(t *testing.T) {
+		is := is.New(t)
+		s := Schema{Fields: []Field{{Name: "Price", Type: moneyType}}}
+		// Nested structs are flattened, so struct values are set to interface{} fields.
+		var row struct{ Price interface{} }
+		is.NoErr(s.CastRow([]string{"3 BRL"}, &row))
+		is.Equal(row.Price, money{3, "BRL"})
+	})
+}
+
+func TestCustomType_Validate(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		is := is.New(t)
+		s, err := Read(strings.NewReader(`{"fields":[{"name":"price","type":"money","format":"eur","constraints":{"minimum":"0 EUR"}}]}`))
+		is.NoErr(err)
+		is.NoErr(s.Validate())
+	})
+	t.Run("InvalidBound", func(t *testing.T) {
+		is := is.New(t)
+		s := Schema{Fields: []Field{{Name: "price", Type: moneyType, Constraints: Constraints{Minimum: "0"}}}}
+		is.True(s.Validate() != nil)
+	})
+	t.Run("UnknownType", func(t *testing.T) {
+		is := is.New(t)
+		s := Schema{Fields: []Field{{Name: "price", Type: "currency"}}}
+		is.True(s.Validate() != nil)
+	})
+}
+
+func TestCustomType_Infer(t *testing.T) {
+	tab := table.FromSlices(
+		[]string{"Price", "Zip", "Mixed", "Account"},
+		[][]string{
+			{"10 EUR", "01234", "10 EUR", "991234567"},
+			{"2.5 USD", "98765", "10", "997654321"},
+		})
+	for _, d := range []struct {
+		desc  string
+		infer func(table.Table, ...InferOpts) (*Schema, error)
+	}{
+		{"Infer", Infer},
+		{"InferImplicitCasting", InferImplicitCasting},
+	} {
+		t.Run(d.desc, func(t *testing.T) {
+			t.Run("Detection", func(t *testing.T) {
+				is := is.New(t)
+				s, err := d.infer(tab)
+				is.NoErr(err)
+				is.Equal(s.Fields[0].Type, moneyType)
+				// Custom types are tried after built-in ones by default.
+				is.Equal(s.Fields[1].Type, IntegerType)
+				// Unless registered using InferBefore.
+				is.Equal(s.Fields[3].Type, accountType)
+			})
+			t.Run("PrecedenceOrder", func(t *testing.T) {
+				is := is.New(t)
+				s, err := d.infer(tab, WithPriorityOrder([]FieldType{zipCodeType, IntegerType, NumberType, moneyType}))
+				is.NoErr(err)
+				is.Equal(s.Fields[0].Type, moneyType)
+				is.Equal(s.Fields[1].Type, zipCodeType)
+				is.Equal(s.Fields[3].Type, IntegerType)
+			})
+		})
+	}
+	t.Run("ImplicitCastingMixed", func(t *testing.T) {
+		is := is.New(t)
+		s, err := InferImplicitCasting(tab)
+		is.NoErr(err)
+		is.Equal(s.Fields[2].Type, StringType)
+	})
+}
@@ -7,20 +7,7 @@ func castDate(format, value string, c Constraints) (time.Time, error) {
 	if err != nil {
 		return y, err
 	}
-	var max, min time.Time
-	if c.Maximum != "" {
-		max, err = castDateWithoutChecks(format, c.Maximum)
-		if err != nil {
-			return max, err
-		}
-	}
-	if c.Minimum != "" {
-		min, err = castDateWithoutChecks(format, c.Minimum)
-		if err != nil {
-			return min, err
-		}
-	}
-	return checkConstraints(y, max, min, DateType)
+	return checkTimeConstraints(y, c, DateType, func(v string) (time.Time, error) { return castDateWithoutChecks(format, v) })
 }
 
 func castDateWithoutChecks(format, value string) (time.Time, error) {
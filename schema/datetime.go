@@ -36,20 +36,7 @@ func castYearMonth(value string, c Constraints) (time.Time, error) {
 	if err != nil {
 		return y, err
 	}
-	var max, min time.Time
-	if c.Maximum != "" {
-		max, err = castYearMonthWithoutChecks(c.Maximum)
-		if err != nil {
-			return y, err
-		}
-	}
-	if c.Minimum != "" {
-		min, err = castYearMonthWithoutChecks(c.Minimum)
-		if err != nil {
-			return y, err
-		}
-	}
-	return checkConstraints(y, max, min, YearMonthType)
+	return checkTimeConstraints(y, c, YearMonthType, func(v string) (time.Time, error) { return castYearMonthWithoutChecks(v) })
 }
 
 func castYearMonthWithoutChecks(value string) (time.Time, error) {
@@ -65,20 +52,7 @@ func castYear(value string, c Constraints) (time.Time, error) {
 	if err != nil {
 		return y, err
 	}
-	var max, min time.Time
-	if c.Maximum != "" {
-		max, err = castYearWithoutChecks(c.Maximum)
-		if err != nil {
-			return y, err
-		}
-	}
-	if c.Minimum != "" {
-		min, err = castYearWithoutChecks(c.Minimum)
-		if err != nil {
-			return y, err
-		}
-	}
-	return checkConstraints(y, max, min, YearType)
+	return checkTimeConstraints(y, c, YearType, func(v string) (time.Time, error) { return castYearWithoutChecks(v) })
 }
 
 func castDateTime(value string, c Constraints) (time.Time, error) {
@@ -86,36 +60,13 @@ func castDateTime(value string, c Constraints) (time.Time, error) {
 	if err != nil {
 		return dt, err
 	}
-	var max, min time.Time
-	if c.Maximum != "" {
-		max, err = castDateTimeWithoutChecks(c.Maximum)
-		if err != nil {
-			return dt, err
-		}
-	}
-	if c.Minimum != "" {
-		min, err = castDateTimeWithoutChecks(c.Minimum)
-		if err != nil {
-			return dt, err
-		}
-	}
-	return checkConstraints(dt, max, min, DateTimeType)
+	return checkTimeConstraints(dt, c, DateTimeType, func(v string) (time.Time, error) { return castDateTimeWithoutChecks(v) })
 }
 
 func castDateTimeWithoutChecks(value string) (time.Time, error) {
 	return time.Parse(time.RFC3339, value)
 }
 
-func checkConstraints(v, max, min time.Time, t FieldType) (time.Time, error) {
-	if !max.IsZero() && v.After(max) {
-		return v, fmt.Errorf("constraint check error: %s:%v > maximum:%v", t, v, max)
-	}
-	if !min.IsZero() && v.Before(min) {
-		return v, fmt.Errorf("constraint check error: %s:%v < minimum:%v", t, v, min)
-	}
-	return v, nil
-}
-
 func castDefaultOrCustomTime(defaultFormat, format, value string) (time.Time, error) {
 	switch format {
 	case "", defaultFieldFormat:
@@ -127,13 +78,18 @@ func castDefaultOrCustomTime(defaultFormat, format, value string) (time.Time, er
 	case AnyDateFormat:
 		return time.Unix(0, 0), fmt.Errorf("any date format not yet supported. Please file an issue at github.com/frictionlessdata/tableschema-go")
 	}
-	goFormat := format
-	for f, s := range strftimeToGoConversionTable {
-		goFormat = strings.Replace(goFormat, f, s, -1)
-	}
-	t, err := time.Parse(goFormat, value)
+	t, err := time.Parse(goTimeLayout(format), value)
 	if err != nil {
 		return t, err
 	}
 	return t.In(time.UTC), nil
 }
+
+// goTimeLayout converts a strftime/strptime format into a Go time layout.
+func goTimeLayout(format string) string {
+	layout := format
+	for f, s := range strftimeToGoConversionTable {
+		layout = strings.Replace(layout, f, s, -1)
+	}
+	return layout
+}
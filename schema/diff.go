@@ -0,0 +1,572 @@
+package schema
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Compatibility describes whether data valid against a schema version is also valid
+// against another version.
+type Compatibility int
+
+// Compatibility levels. They are bit sets, so the compatibility of a set of changes
+// is the intersection (&) of the compatibility of each change.
+const (
+	// Breaking means neither version can read data valid against the other.
+	Breaking Compatibility = 0
+	// BackwardCompatible means the new schema can read data valid against the old one.
+	BackwardCompatible Compatibility = 1
+	// ForwardCompatible means the old schema can read data valid against the new one.
+	ForwardCompatible Compatibility = 2
+	// FullyCompatible means both backward and forward compatible.
+	FullyCompatible = BackwardCompatible | ForwardCompatible
+)
+
+func (c Compatibility) String() string {
+	switch c {
+	case FullyCompatible:
+		return "fully compatible"
+	case BackwardCompatible:
+		return "backward compatible"
+	case ForwardCompatible:
+		return "forward compatible"
+	}
+	return "breaking"
+}
+
+// ChangeKind describes the kind of a schema change.
+type ChangeKind string
+
+// Kinds of schema changes.
+const (
+	FieldAdded           ChangeKind = "field added"
+	FieldRemoved         ChangeKind = "field removed"
+	FieldRenamed         ChangeKind = "field renamed"
+	TypeChanged          ChangeKind = "type changed"
+	FormatChanged        ChangeKind = "format changed"
+	ConstraintTightened  ChangeKind = "constraint tightened"
+	ConstraintLoosened   ChangeKind = "constraint loosened"
+	ConstraintChanged    ChangeKind = "constraint changed"
+	PrimaryKeyChanged    ChangeKind = "primary key changed"
+	ForeignKeysChanged   ChangeKind = "foreign keys changed"
+	MissingValuesChanged ChangeKind = "missing values changed"
+	PropertyChanged      ChangeKind = "property changed"
+)
+
+// Change describes a single difference between two schema versions.
+type Change struct {
+	Kind ChangeKind
+	// Field is the name of the field in the new schema (the old schema for removed
+	// fields). It is empty for schema-level changes.
+	Field string
+	// OldField is the name of the field in the old schema, if it was renamed.
+	OldField string
+	// Property is the descriptor property which changed, for instance "type" or
+	// "constraints/maximum".
+	Property string
+	// Old and New hold the descriptor values, encoded as strings.
+	Old, New string
+	// Compatibility of the change.
+	Compatibility Compatibility
+}
+
+func (c Change) String() string {
+	var b strings.Builder
+	if c.Field != "" {
+		fmt.Fprintf(&b, "field %q: ", c.Field)
+	}
+	b.WriteString(string(c.Kind))
+	if c.Property != "" {
+		fmt.Fprintf(&b, " %s", c.Property)
+	}
+	if c.Old != "" || c.New != "" {
+		fmt.Fprintf(&b, " from %q to %q", c.Old, c.New)
+	}
+	fmt.Fprintf(&b, " (%s)", c.Compatibility)
+	return b.String()
+}
+
+// SchemaDiff lists the differences between two schema versions.
+type SchemaDiff struct {
+	Changes []Change
+	// Compatibility of the new schema version in respect to the old one, considering
+	// all changes.
+	Compatibility Compatibility
+}
+
+func (d *SchemaDiff) add(c Change) {
+	d.Changes = append(d.Changes, c)
+	d.Compatibility &= c.Compatibility
+}
+
+// Diff reports the differences between the old and new versions of a schema, along with
+// their compatibility. Fields are matched by name, like when reading tables with headers.
+// A field removed and a field added at the same position, with the same type and format,
+// are reported as a rename.
+//
+// Compatibility follows the usual schema evolution rules: the new schema is backward
+// compatible if it can read data valid against the old one (for instance, adding an
+// optional field, widening a type or loosening a constraint) and forward compatible if
+// the old schema can read data valid against the new one (for instance, removing an
+// optional field or tightening a constraint). Renames and incompatible type changes
+// are breaking.
+func Diff(old, new *Schema) *SchemaDiff {
+	d := &SchemaDiff{Compatibility: FullyCompatible}
+	var removed, added []int
+	for i := range old.Fields {
+		if !new.HasField(old.Fields[i].Name) {
+			removed = append(removed, i)
+		}
+	}
+	for i := range new.Fields {
+		if !old.HasField(new.Fields[i].Name) {
+			added = append(added, i)
+		}
+	}
+	renamed := make(map[int]int) // new field index to old field index.
+	for _, i := range removed {
+		for _, j := range added {
+			of, nf := &old.Fields[i], &new.Fields[j]
+			if i == j && fieldType(of) == fieldType(nf) && fieldFormat(of) == fieldFormat(nf) {
+				renamed[j] = i
+			}
+		}
+	}
+	for _, i := range removed {
+		if isRenamed(renamed, i) {
+			continue
+		}
+		f := &old.Fields[i]
+		c := FullyCompatible
+		if old.isRequired(f) {
+			// Old readers require the field.
+			c = BackwardCompatible
+		}
+		d.add(Change{Kind: FieldRemoved, Field: f.Name, Compatibility: c})
+	}
+	for i := range new.Fields {
+		nf := &new.Fields[i]
+		if oi, ok := renamed[i]; ok {
+			of := &old.Fields[oi]
+			d.add(Change{Kind: FieldRenamed, Field: nf.Name, OldField: of.Name, Property: "name", Old: of.Name, New: nf.Name, Compatibility: Breaking})
+			d.diffField(of, nf, old, new)
+			continue
+		}
+		of, _ := old.GetField(nf.Name)
+		if of == nil {
+			c := FullyCompatible
+			if new.isRequired(nf) {
+				// Old data does not have the field.
+				c = ForwardCompatible
+			}
+			d.add(Change{Kind: FieldAdded, Field: nf.Name, Compatibility: c})
+			continue
+		}
+		d.diffField(of, nf, old, new)
+	}
+	d.diffKeys(old, new)
+	if !reflect.DeepEqual(effectiveMissingValues(nil, old), effectiveMissingValues(nil, new)) {
+		d.add(missingValuesChange("", effectiveMissingValues(nil, old), effectiveMissingValues(nil, new)))
+	}
+	return d
+}
+
+func isRenamed(renamed map[int]int, oldIndex int) bool {
+	for _, i := range renamed {
+		if i == oldIndex {
+			return true
+		}
+	}
+	return false
+}
+
+func fieldType(f *Field) FieldType {
+	if f.Type == "" {
+		return defaultFieldType
+	}
+	return f.Type
+}
+
+func fieldFormat(f *Field) string {
+	if f.Format == "" {
+		return defaultFieldFormat
+	}
+	return f.Format
+}
+
+// typeChangeCompatibility returns the compatibility of changing the field type: a
+// change is backward compatible if the new field reads the old values and forward
+// compatible if the old field reads the new ones (see readsAs). Other widenings, like
+// date to datetime, are breaking: old values must be converted, which Migrate does.
+func typeChangeCompatibility(from, to FieldType) Compatibility {
+	c := Breaking
+	if readsAs(from, to) {
+		c |= BackwardCompatible
+	}
+	if readsAs(to, from) {
+		c |= ForwardCompatible
+	}
+	return c
+}
+
+// readableTypes maps types to the other types able to cast all their values, as
+// encoded by the default formats. String and any fields read values of all types.
+var readableTypes = map[FieldType][]FieldType{
+	IntegerType: {NumberType},
+	YearType:    {IntegerType, NumberType},
+}
+
+// readsAs returns true if fields of type to cast all values of type from, without
+// any conversion. Unlike implicit casts (see widensTo), a date is not read as
+// datetime, for instance.
+func readsAs(from, to FieldType) bool {
+	if from == to || to == StringType || to == AnyType {
+		return true
+	}
+	for _, t := range readableTypes[from] {
+		if t == to {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *SchemaDiff) diffField(of, nf *Field, old, new *Schema) {
+	ot, nt := fieldType(of), fieldType(nf)
+	if ot != nt {
+		d.add(Change{Kind: TypeChanged, Field: nf.Name, Property: "type", Old: string(ot), New: string(nt), Compatibility: typeChangeCompatibility(ot, nt)})
+		// Constraints are encoded like values, so they can not be compared.
+		return
+	}
+	if ofmt, nfmt := fieldFormat(of), fieldFormat(nf); ofmt != nfmt {
+		c := Breaking
+		// String formats only restrict values, the default format accepts all strings.
+		if nt == StringType && ofmt != stringBinary && nfmt != stringBinary {
+			switch {
+			case nfmt == defaultFieldFormat:
+				c = BackwardCompatible
+			case ofmt == defaultFieldFormat:
+				c = ForwardCompatible
+			}
+		}
+		d.add(Change{Kind: FormatChanged, Field: nf.Name, Property: "format", Old: ofmt, New: nfmt, Compatibility: c})
+	}
+	for _, p := range []struct {
+		name     string
+		old, new interface{}
+	}{
+		{"itemType", of.listItem().Type, nf.listItem().Type},
+		{"delimiter", of.listDelimiter(), nf.listDelimiter()},
+		{"trueValues", of.TrueValues, nf.TrueValues},
+		{"falseValues", of.FalseValues, nf.FalseValues},
+		{"decimalChar", of.DecimalChar, nf.DecimalChar},
+		{"groupChar", of.GroupChar, nf.GroupChar},
+		{"bareNumber", of.BareNumber, nf.BareNumber},
+		{"categoriesOrdered", of.CategoriesOrdered, nf.CategoriesOrdered},
+	} {
+		if !reflect.DeepEqual(p.old, p.new) {
+			d.add(Change{Kind: PropertyChanged, Field: nf.Name, Property: p.name, Old: fmt.Sprint(p.old), New: fmt.Sprint(p.new), Compatibility: Breaking})
+		}
+	}
+	if nt == CategoricalType {
+		d.diffCategories(of, nf)
+	}
+	d.diffConstraints(of, nf, old, new)
+	// Schema-level missing values are compared once, by Diff.
+	if of.MissingValuesPlaceholder != nil || nf.MissingValuesPlaceholder != nil {
+		omv, nmv := effectiveMissingValues(of, old), effectiveMissingValues(nf, new)
+		if !reflect.DeepEqual(omv, nmv) {
+			d.add(missingValuesChange(nf.Name, omv, nmv))
+		}
+	}
+}
+
+// diffCategories compares categories like enum values: adding categories
+// loosens the field, removing them tightens it.
+func (d *SchemaDiff) diffCategories(of, nf *Field) {
+	toSet := func(categories []Category) map[string]struct{} {
+		set := make(map[string]struct{}, len(categories))
+		for _, c := range categories {
+			set[c.raw()] = struct{}{}
+		}
+		return set
+	}
+	o, n := toSet(of.Categories), toSet(nf.Categories)
+	if reflect.DeepEqual(o, n) {
+		return
+	}
+	cmp, ok := 0, false
+	switch {
+	case isSubset(n, o):
+		cmp, ok = 1, true
+	case isSubset(o, n):
+		cmp, ok = -1, true
+	}
+	c := constraintChange(nf.Name, "", fmt.Sprint(of.Categories), fmt.Sprint(nf.Categories), cmp, ok)
+	c.Property = "categories"
+	d.add(c)
+}
+
+func (d *SchemaDiff) diffConstraints(of, nf *Field, old, new *Schema) {
+	oc, nc := of.Constraints, nf.Constraints
+	flag := func(name string, o, n bool) {
+		switch {
+		case !o && n:
+			d.add(Change{Kind: ConstraintTightened, Field: nf.Name, Property: "constraints/" + name, Old: "false", New: "true", Compatibility: ForwardCompatible})
+		case o && !n:
+			d.add(Change{Kind: ConstraintLoosened, Field: nf.Name, Property: "constraints/" + name, Old: "true", New: "false", Compatibility: BackwardCompatible})
+		}
+	}
+	flag(requiredConstraint, old.isRequired(of), new.isRequired(nf))
+	flag(uniqueConstraint, oc.Unique, nc.Unique)
+
+	caster := nf.boundCaster()
+	for _, b := range []struct {
+		name     string
+		old, new string
+		lower    bool
+	}{
+		{minimumConstraint, oc.Minimum, nc.Minimum, true},
+		{exclusiveMinimumConstraint, oc.ExclusiveMinimum, nc.ExclusiveMinimum, true},
+		{maximumConstraint, oc.Maximum, nc.Maximum, false},
+		{exclusiveMaximumConstraint, oc.ExclusiveMaximum, nc.ExclusiveMaximum, false},
+	} {
+		if b.old == b.new {
+			continue
+		}
+		cmp, ok := 0, true
+		switch {
+		case b.old == "":
+			cmp = 1 // Adding a bound tightens, like moving it inwards.
+		case b.new == "":
+			cmp = -1
+		default:
+			cmp, ok = compareBounds(&caster, b.new, b.old)
+			if !b.lower {
+				cmp = -cmp
+			}
+		}
+		d.add(constraintChange(nf.Name, b.name, b.old, b.new, cmp, ok))
+	}
+	for _, l := range []struct {
+		name     string
+		old, new int
+		lower    bool
+	}{
+		{minLengthConstraint, oc.MinLength, nc.MinLength, true},
+		{maxLengthConstraint, oc.MaxLength, nc.MaxLength, false},
+	} {
+		if l.old == l.new {
+			continue
+		}
+		var cmp int
+		switch {
+		case l.old == 0 && !l.lower:
+			cmp = 1
+		case l.new == 0 && !l.lower:
+			cmp = -1
+		case l.new > l.old == l.lower:
+			cmp = 1
+		default:
+			cmp = -1
+		}
+		d.add(constraintChange(nf.Name, l.name, fmt.Sprint(l.old), fmt.Sprint(l.new), cmp, true))
+	}
+	if oc.Pattern != nc.Pattern {
+		cmp, ok := 0, false
+		switch {
+		case oc.Pattern == "":
+			cmp, ok = 1, true
+		case nc.Pattern == "":
+			cmp, ok = -1, true
+		}
+		d.add(constraintChange(nf.Name, patternConstraint, oc.Pattern, nc.Pattern, cmp, ok))
+	}
+	oe, _ := of.compileEnum()
+	ne, _ := nf.compileEnum()
+	if len(oc.Enum) == 0 {
+		oe = nil
+	}
+	if len(nc.Enum) == 0 {
+		ne = nil
+	}
+	if !reflect.DeepEqual(oe, ne) {
+		cmp, ok := 0, false
+		switch {
+		case oe == nil:
+			cmp, ok = 1, true
+		case ne == nil:
+			cmp, ok = -1, true
+		case isSubset(ne, oe):
+			cmp, ok = 1, true
+		case isSubset(oe, ne):
+			cmp, ok = -1, true
+		}
+		d.add(constraintChange(nf.Name, enumConstraint, fmt.Sprint(oc.Enum), fmt.Sprint(nc.Enum), cmp, ok))
+	}
+}
+
+// constraintChange returns the change of a constraint, which is tightened if cmp is
+// positive and loosened if it is negative. It is reported as changed if the constraint
+// values can not be compared.
+func constraintChange(field, name, old, new string, cmp int, comparable bool) Change {
+	c := Change{Kind: ConstraintChanged, Field: field, Property: "constraints/" + name, Old: old, New: new, Compatibility: Breaking}
+	switch {
+	case !comparable:
+	case cmp > 0:
+		c.Kind, c.Compatibility = ConstraintTightened, ForwardCompatible
+	case cmp < 0:
+		c.Kind, c.Compatibility = ConstraintLoosened, BackwardCompatible
+	}
+	return c
+}
+
+// compareBounds casts both bounds using the field and compares them.
+func compareBounds(f *Field, a, b string) (int, bool) {
+	av, err := f.Cast(a)
+	if err != nil {
+		return 0, false
+	}
+	bv, err := f.Cast(b)
+	if err != nil {
+		return 0, false
+	}
+	return compareValues(av, bv)
+}
+
+// compareValues compares values of the same ordered type (int64, float64, time.Time
+// and time.Duration). It returns false if the values are not comparable.
+func compareValues(a, b interface{}) (int, bool) {
+	switch av := a.(type) {
+	case int64:
+		if bv, ok := b.(int64); ok {
+			return compareFloats(float64(av), float64(bv)), true
+		}
+	case float64:
+		if bv, ok := b.(float64); ok {
+			return compareFloats(av, bv), true
+		}
+	case time.Duration:
+		if bv, ok := b.(time.Duration); ok {
+			return compareFloats(float64(av), float64(bv)), true
+		}
+	case time.Time:
+		if bv, ok := b.(time.Time); ok {
+			switch {
+			case av.Before(bv):
+				return -1, true
+			case av.After(bv):
+				return 1, true
+			}
+			return 0, true
+		}
+	}
+	return 0, false
+}
+
+func compareFloats(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func isSubset(a, b map[string]struct{}) bool {
+	for k := range a {
+		if _, ok := b[k]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// effectiveMissingValues returns the sorted missing values in effect for the field, or for the
+// schema if the field is nil.
+func effectiveMissingValues(f *Field, s *Schema) []string {
+	var ret []string
+	switch {
+	case f != nil && f.MissingValues != nil:
+		for v := range f.MissingValues {
+			ret = append(ret, v)
+		}
+	case s.MissingValues != nil:
+		ret = append(ret, s.MissingValues...)
+	default:
+		ret = []string{defaultMissingValue}
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+// missingValuesChange returns the change of missing values. More missing values means
+// more cells are accepted (as null), which is like loosening a constraint.
+func missingValuesChange(field string, old, new []string) Change {
+	toSet := func(values []string) map[string]struct{} {
+		set := make(map[string]struct{}, len(values))
+		for _, v := range values {
+			set[v] = struct{}{}
+		}
+		return set
+	}
+	o, n := toSet(old), toSet(new)
+	c := Change{Kind: MissingValuesChanged, Field: field, Property: "missingValues", Old: fmt.Sprint(old), New: fmt.Sprint(new), Compatibility: Breaking}
+	switch {
+	case isSubset(o, n):
+		c.Compatibility = BackwardCompatible
+	case isSubset(n, o):
+		c.Compatibility = ForwardCompatible
+	}
+	return c
+}
+
+func (d *SchemaDiff) diffKeys(old, new *Schema) {
+	if !reflect.DeepEqual(nonNil(old.PrimaryKeys), nonNil(new.PrimaryKeys)) {
+		c := Breaking
+		switch {
+		case len(old.PrimaryKeys) == 0:
+			c = ForwardCompatible // Adding a primary key restricts data.
+		case len(new.PrimaryKeys) == 0:
+			c = BackwardCompatible
+		}
+		d.add(Change{Kind: PrimaryKeyChanged, Property: "primaryKey", Old: strings.Join(old.PrimaryKeys, ","), New: strings.Join(new.PrimaryKeys, ","), Compatibility: c})
+	}
+	fkString := func(fks []ForeignKeys) []string {
+		var ret []string
+		for _, fk := range fks {
+			ret = append(ret, fmt.Sprintf("%s->%s(%s)", strings.Join(fk.Fields, ","), fk.Reference.Resource, strings.Join(fk.Reference.Fields, ",")))
+		}
+		sort.Strings(ret)
+		return ret
+	}
+	ofks, nfks := fkString(old.ForeignKeys), fkString(new.ForeignKeys)
+	if !reflect.DeepEqual(ofks, nfks) {
+		o, n := make(map[string]struct{}), make(map[string]struct{})
+		for _, fk := range ofks {
+			o[fk] = struct{}{}
+		}
+		for _, fk := range nfks {
+			n[fk] = struct{}{}
+		}
+		c := Breaking
+		switch {
+		case isSubset(o, n):
+			c = ForwardCompatible // Foreign keys were only added, which restricts data.
+		case isSubset(n, o):
+			c = BackwardCompatible
+		}
+		d.add(Change{Kind: ForeignKeysChanged, Property: "foreignKeys", Old: strings.Join(ofks, " "), New: strings.Join(nfks, " "), Compatibility: c})
+	}
+}
+
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
@@ -0,0 +1,218 @@
+package schema
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func mustReadSchema(t *testing.T, descriptor string) *Schema {
+	s, err := Read(strings.NewReader(descriptor))
+	if err != nil {
+		t.Fatalf("invalid descriptor %s: %v", descriptor, err)
+	}
+	return s
+}
+
+func TestDiff(t *testing.T) {
+	data := []struct {
+		Desc  string
+		Old   string
+		New   string
+		Want  []Change
+		Compt Compatibility
+	}{
+		{"Equal",
+			`{"fields":[{"name":"a", "type":"integer", "constraints":{"minimum":"1"}}], "primaryKey":"a"}`,
+			`{"fields":[{"name":"a", "type":"integer", "constraints":{"minimum":"1"}}], "primaryKey":"a"}`,
+			nil, FullyCompatible,
+		},
+		{"OptionalFieldAdded",
+			`{"fields":[{"name":"a"}]}`,
+			`{"fields":[{"name":"a"}, {"name":"b"}]}`,
+			[]Change{{Kind: FieldAdded, Field: "b", Compatibility: FullyCompatible}}, FullyCompatible,
+		},
+		{"RequiredFieldAdded",
+			`{"fields":[{"name":"a"}]}`,
+			`{"fields":[{"name":"a"}, {"name":"b", "constraints":{"required":true}}]}`,
+			[]Change{{Kind: FieldAdded, Field: "b", Compatibility: ForwardCompatible}}, ForwardCompatible,
+		},
+		{"RequiredFieldRemoved",
+			`{"fields":[{"name":"a"}, {"name":"b", "constraints":{"required":true}}]}`,
+			`{"fields":[{"name":"a"}]}`,
+			[]Change{{Kind: FieldRemoved, Field: "b", Compatibility: BackwardCompatible}}, BackwardCompatible,
+		},
+		{"Renamed",
+			`{"fields":[{"name":"a", "type":"integer"}, {"name":"b"}]}`,
+			`{"fields":[{"name":"c", "type":"integer"}, {"name":"b"}]}`,
+			[]Change{{Kind: FieldRenamed, Field: "c", OldField: "a", Property: "name", Old: "a", New: "c", Compatibility: Breaking}}, Breaking,
+		},
+		{"NotRenamedIfTypeChanged",
+			`{"fields":[{"name":"a", "type":"integer"}]}`,
+			`{"fields":[{"name":"c", "type":"date"}]}`,
+			[]Change{
+				{Kind: FieldRemoved, Field: "a", Compatibility: FullyCompatible},
+				{Kind: FieldAdded, Field: "c", Compatibility: FullyCompatible},
+			}, FullyCompatible,
+		},
+		{"TypeWidened",
+			`{"fields":[{"name":"a", "type":"integer"}]}`,
+			`{"fields":[{"name":"a", "type":"number"}]}`,
+			[]Change{{Kind: TypeChanged, Field: "a", Property: "type", Old: "integer", New: "number", Compatibility: BackwardCompatible}}, BackwardCompatible,
+		},
+		{"TypeNarrowed",
+			`{"fields":[{"name":"a", "type":"number"}]}`,
+			`{"fields":[{"name":"a", "type":"integer"}]}`,
+			[]Change{{Kind: TypeChanged, Field: "a", Property: "type", Old: "number", New: "integer", Compatibility: ForwardCompatible}}, ForwardCompatible,
+		},
+		{"TypeWidenedByConversion",
+			`{"fields":[{"name":"a", "type":"date"}]}`,
+			`{"fields":[{"name":"a", "type":"datetime"}]}`,
+			[]Change{{Kind: TypeChanged, Field: "a", Property: "type", Old: "date", New: "datetime", Compatibility: Breaking}}, Breaking,
+		},
+		{"TypeChanged",
+			`{"fields":[{"name":"a", "type":"duration"}]}`,
+			`{"fields":[{"name":"a", "type":"geopoint"}]}`,
+			[]Change{{Kind: TypeChanged, Field: "a", Property: "type", Old: "duration", New: "geopoint", Compatibility: Breaking}}, Breaking,
+		},
+		{"StringFormatRemoved",
+			`{"fields":[{"name":"a", "format":"email"}]}`,
+			`{"fields":[{"name":"a"}]}`,
+			[]Change{{Kind: FormatChanged, Field: "a", Property: "format", Old: "email", New: "default", Compatibility: BackwardCompatible}}, BackwardCompatible,
+		},
+		{"DateFormatChanged",
+			`{"fields":[{"name":"a", "type":"date"}]}`,
+			`{"fields":[{"name":"a", "type":"date", "format":"%d/%m/%Y"}]}`,
+			[]Change{{Kind: FormatChanged, Field: "a", Property: "format", Old: "default", New: "%d/%m/%Y", Compatibility: Breaking}}, Breaking,
+		},
+		{"BoundsTightened",
+			`{"fields":[{"name":"a", "type":"integer", "constraints":{"minimum":"1", "maximum":"10"}}]}`,
+			`{"fields":[{"name":"a", "type":"integer", "constraints":{"minimum":"2", "maximum":"9", "exclusiveMaximum":"9"}}]}`,
+			[]Change{
+				{Kind: ConstraintTightened, Field: "a", Property: "constraints/minimum", Old: "1", New: "2", Compatibility: ForwardCompatible},
+				{Kind: ConstraintTightened, Field: "a", Property: "constraints/maximum", Old: "10", New: "9", Compatibility: ForwardCompatible},
+				{Kind: ConstraintTightened, Field: "a", Property: "constraints/exclusiveMaximum", Old: "", New: "9", Compatibility: ForwardCompatible},
+			}, ForwardCompatible,
+		},
+		{"BoundsLoosened",
+			`{"fields":[{"name":"a", "type":"date", "constraints":{"minimum":"2015-01-02", "maximum":"2016-01-01"}}]}`,
+			`{"fields":[{"name":"a", "type":"date", "constraints":{"minimum":"2015-01-01"}}]}`,
+			[]Change{
+				{Kind: ConstraintLoosened, Field: "a", Property: "constraints/minimum", Old: "2015-01-02", New: "2015-01-01", Compatibility: BackwardCompatible},
+				{Kind: ConstraintLoosened, Field: "a", Property: "constraints/maximum", Old: "2016-01-01", New: "", Compatibility: BackwardCompatible},
+			}, BackwardCompatible,
+		},
+		{"LengthAndFlags",
+			`{"fields":[{"name":"a", "constraints":{"minLength":1, "maxLength":5, "unique":true}}]}`,
+			`{"fields":[{"name":"a", "constraints":{"minLength":2, "maxLength":6, "required":true}}]}`,
+			[]Change{
+				{Kind: ConstraintTightened, Field: "a", Property: "constraints/required", Old: "false", New: "true", Compatibility: ForwardCompatible},
+				{Kind: ConstraintLoosened, Field: "a", Property: "constraints/unique", Old: "true", New: "false", Compatibility: BackwardCompatible},
+				{Kind: ConstraintTightened, Field: "a", Property: "constraints/minLength", Old: "1", New: "2", Compatibility: ForwardCompatible},
+				{Kind: ConstraintLoosened, Field: "a", Property: "constraints/maxLength", Old: "5", New: "6", Compatibility: BackwardCompatible},
+			}, Breaking,
+		},
+		{"PatternChanged",
+			`{"fields":[{"name":"a", "constraints":{"pattern":"a.*"}}]}`,
+			`{"fields":[{"name":"a", "constraints":{"pattern":"b.*"}}]}`,
+			[]Change{{Kind: ConstraintChanged, Field: "a", Property: "constraints/pattern", Old: "a.*", New: "b.*", Compatibility: Breaking}}, Breaking,
+		},
+		{"EnumLoosened",
+			`{"fields":[{"name":"a", "type":"number", "constraints":{"enum":[1, 2]}}]}`,
+			`{"fields":[{"name":"a", "type":"number", "constraints":{"enum":["1.0", 2, 3]}}]}`,
+			[]Change{{Kind: ConstraintLoosened, Field: "a", Property: "constraints/enum", Old: "[1 2]", New: "[1.0 2 3]", Compatibility: BackwardCompatible}}, BackwardCompatible,
+		},
+		{"CategoriesRemoved",
+			`{"fields":[{"name":"a", "type":"categorical", "categories":["x", "y"]}]}`,
+			`{"fields":[{"name":"a", "type":"categorical", "categories":["x"]}]}`,
+			[]Change{{Kind: ConstraintTightened, Field: "a", Property: "categories", Old: "[{x } {y }]", New: "[{x }]", Compatibility: ForwardCompatible}}, ForwardCompatible,
+		},
+		{"PropertyChanged",
+			`{"fields":[{"name":"a", "type":"number"}]}`,
+			`{"fields":[{"name":"a", "type":"number", "decimalChar":","}]}`,
+			[]Change{{Kind: PropertyChanged, Field: "a", Property: "decimalChar", Old: ".", New: ",", Compatibility: Breaking}}, Breaking,
+		},
+		{"PrimaryKeyAdded",
+			`{"fields":[{"name":"a"}]}`,
+			`{"fields":[{"name":"a"}], "primaryKey":"a"}`,
+			[]Change{
+				{Kind: ConstraintTightened, Field: "a", Property: "constraints/required", Old: "false", New: "true", Compatibility: ForwardCompatible},
+				{Kind: PrimaryKeyChanged, Property: "primaryKey", Old: "", New: "a", Compatibility: ForwardCompatible},
+			}, ForwardCompatible,
+		},
+		{"ForeignKeyRemoved",
+			`{"fields":[{"name":"a"}, {"name":"b"}], "foreignKeys":[{"fields":"a", "reference":{"resource":"", "fields":"b"}}]}`,
+			`{"fields":[{"name":"a"}, {"name":"b"}]}`,
+			[]Change{{Kind: ForeignKeysChanged, Property: "foreignKeys", Old: "a->(b)", New: "", Compatibility: BackwardCompatible}}, BackwardCompatible,
+		},
+		{"MissingValuesAdded",
+			`{"fields":[{"name":"a"}]}`,
+			`{"fields":[{"name":"a"}], "missingValues":["", "NA"]}`,
+			[]Change{{Kind: MissingValuesChanged, Property: "missingValues", Old: "[]", New: "[ NA]", Compatibility: BackwardCompatible}}, BackwardCompatible,
+		},
+		{"FieldMissingValuesRemoved",
+			`{"fields":[{"name":"a", "missingValues":["", "-"]}], "missingValues":["", "NA"]}`,
+			`{"fields":[{"name":"a"}], "missingValues":["", "NA"]}`,
+			[]Change{{Kind: MissingValuesChanged, Field: "a", Property: "missingValues", Old: "[ -]", New: "[ NA]", Compatibility: Breaking}}, Breaking,
+		},
+	}
+	for _, d := range data {
+		t.Run(d.Desc, func(t *testing.T) {
+			is := is.New(t)
+			got := Diff(mustReadSchema(t, d.Old), mustReadSchema(t, d.New))
+			if !reflect.DeepEqual(got.Changes, d.Want) {
+				t.Fatalf("got changes:%v want:%v", got.Changes, d.Want)
+			}
+			is.Equal(got.Compatibility, d.Compt)
+		})
+	}
+}
+
+func TestChange_String(t *testing.T) {
+	is := is.New(t)
+	c := Change{Kind: ConstraintTightened, Field: "a", Property: "constraints/maximum", Old: "10", New: "9", Compatibility: ForwardCompatible}
+	is.Equal(c.String(), `field "a": constraint tightened constraints/maximum from "10" to "9" (forward compatible)`)
+	c = Change{Kind: FieldAdded, Field: "b", Compatibility: FullyCompatible}
+	is.Equal(c.String(), `field "b": field added (fully compatible)`)
+}
+
+func TestReadsAs(t *testing.T) {
+	// Values of each type, encoded by the default format.
+	values := map[FieldType][]string{
+		BooleanType:   {"true", "no"},
+		IntegerType:   {"10", "-3"},
+		NumberType:    {"1.5", "-3", "10"},
+		YearType:      {"2016"},
+		YearMonthType: {"2017-03"},
+		DateType:      {"2015-01-02"},
+		DateTimeType:  {"2015-01-02T10:00:00Z"},
+		TimeType:      {"10:00:00"},
+		DurationType:  {"P1DT2H"},
+		GeoPointType:  {"10.5,20", "10.5, 20"},
+		ArrayType:     {`[1,"a"]`},
+		ObjectType:    {`{"a":1}`},
+		StringType:    {"foo"},
+		AnyType:       {"foo"},
+	}
+	for from, vs := range values {
+		for to := range values {
+			if to == ObjectType && from != ObjectType {
+				// Object fields decode any JSON value, like 10, which is not an object.
+				continue
+			}
+			f := withDefaults()
+			f.Type = to
+			reads := true
+			for _, v := range vs {
+				if _, err := f.Cast(v); err != nil {
+					reads = false
+				}
+			}
+			if readsAs(from, to) != reads {
+				t.Errorf("readsAs(%s, %s):%t, but %s fields cast %q:%t", from, to, !reads, to, vs, reads)
+			}
+		}
+	}
+}
@@ -18,7 +18,30 @@ const (
 	hoursInDay   = time.Duration(24) * time.Hour
 )
 
-func castDuration(value string) (time.Duration, error) {
+func castDuration(value string, c Constraints) (time.Duration, error) {
+	d, err := castDurationWithoutChecks(value)
+	if err != nil {
+		return 0, err
+	}
+	err = checkBounds(DurationType, d, c, func(bound string) (int, error) {
+		b, err := castDurationWithoutChecks(bound)
+		switch {
+		case err != nil:
+			return 0, err
+		case d < b:
+			return -1, nil
+		case d > b:
+			return 1, nil
+		}
+		return 0, nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return d, nil
+}
+
+func castDurationWithoutChecks(value string) (time.Duration, error) {
 	matches := durationRegexp.FindStringSubmatch(value)
 	if len(matches) == 0 {
 		return 0, fmt.Errorf("invalid duration:\"%s\"", value)
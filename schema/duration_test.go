@@ -26,7 +26,7 @@ func TestCastDuration_Success(t *testing.T) {
 	for _, d := range data {
 		t.Run(d.desc, func(t *testing.T) {
 			is := is.New(t)
-			got, err := castDuration(d.value)
+			got, err := castDuration(d.value, Constraints{})
 			is.NoErr(err)
 			is.Equal(got, d.want)
 		})
@@ -43,7 +43,7 @@ func TestCastDuration_Error(t *testing.T) {
 	for _, d := range data {
 		t.Run(d.desc, func(t *testing.T) {
 			is := is.New(t)
-			_, err := castDuration(d.value)
+			_, err := castDuration(d.value, Constraints{})
 			is.True(err != nil)
 		})
 	}
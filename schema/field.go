@@ -27,22 +27,39 @@ type FieldType string
 
 // Field types.
 const (
-	IntegerType   FieldType = "integer"
-	StringType    FieldType = "string"
-	BooleanType   FieldType = "boolean"
-	NumberType    FieldType = "number"
-	DateType      FieldType = "date"
-	ObjectType    FieldType = "object"
-	ArrayType     FieldType = "array"
-	DateTimeType  FieldType = "datetime"
-	TimeType      FieldType = "time"
-	YearMonthType FieldType = "yearmonth"
-	YearType      FieldType = "year"
-	DurationType  FieldType = "duration"
-	GeoPointType  FieldType = "geopoint"
-	AnyType       FieldType = "any"
+	IntegerType     FieldType = "integer"
+	StringType      FieldType = "string"
+	BooleanType     FieldType = "boolean"
+	NumberType      FieldType = "number"
+	DateType        FieldType = "date"
+	ObjectType      FieldType = "object"
+	ArrayType       FieldType = "array"
+	DateTimeType    FieldType = "datetime"
+	TimeType        FieldType = "time"
+	YearMonthType   FieldType = "yearmonth"
+	YearType        FieldType = "year"
+	DurationType    FieldType = "duration"
+	GeoPointType    FieldType = "geopoint"
+	AnyType         FieldType = "any"
+	ListType        FieldType = "list"
+	CategoricalType FieldType = "categorical"
 )
 
+// fieldTypes holds all field types supported by Field.Cast.
+var fieldTypes = map[FieldType]struct{}{
+	IntegerType: {}, StringType: {}, BooleanType: {}, NumberType: {}, DateType: {}, ObjectType: {},
+	ArrayType: {}, DateTimeType: {}, TimeType: {}, YearMonthType: {}, YearType: {}, DurationType: {},
+	GeoPointType: {}, AnyType: {}, ListType: {}, CategoricalType: {},
+}
+
+func isKnownType(t FieldType) bool {
+	if _, ok := fieldTypes[t]; ok {
+		return true
+	}
+	_, ok := customCaster(t)
+	return ok
+}
+
 // Formats.
 const (
 	AnyDateFormat = "any"
@@ -60,8 +77,17 @@ type Constraints struct {
 	// This constrain is only relevant for Schema.CastTable
 	Unique bool `json:"unique,omitempty"`
 
-	Maximum         string `json:"maximum,omitempty"`
-	Minimum         string `json:"minimum,omitempty"`
+	// Maximum and Minimum values (inclusive) of the field, encoded like the field values.
+	// They apply to integer, number, date, time, datetime, year, yearmonth and duration
+	// fields, as well as list items. Descriptors may also declare them as JSON numbers.
+	Maximum string `json:"maximum,omitempty"`
+	Minimum string `json:"minimum,omitempty"`
+	// ExclusiveMaximum and ExclusiveMinimum are like Maximum and Minimum, but do not
+	// allow the bound value itself.
+	ExclusiveMaximum string `json:"exclusiveMaximum,omitempty"`
+	ExclusiveMinimum string `json:"exclusiveMinimum,omitempty"`
+	// MinLength and MaxLength apply to the number of characters of strings (or bytes,
+	// for the binary format) and to the number of elements of arrays, objects and lists.
 	MinLength       int    `json:"minLength,omitempty"`
 	MaxLength       int    `json:"maxLength,omitempty"`
 	Pattern         string `json:"pattern,omitempty"`
@@ -103,34 +129,106 @@ type Field struct {
 	// are going to be stripped. Default value is true:
 	BareNumber bool `json:"bareNumber,omitempty"`
 
+	// String properties.
+
+	// UUIDVersions lists the UUID versions accepted by the "uuid" format. The nil UUID is
+	// accepted by listing version 0. Defaults to version 4 only.
+	UUIDVersions []int `json:"uuidVersions,omitempty"`
+
+	// Array properties.
+
+	// ArrayItem describes the items of an array field. Each item is cast using this
+	// descriptor, in the same way top-level fields are. That includes missing values:
+	// null items and string items which are missing values of the descriptor (by
+	// default, "") are cast to nil, or rejected if the item is required. Optional.
+	ArrayItem *Field `json:"arrayItem,omitempty"`
+
+	// Object properties.
+
+	// Properties describes the properties of an object field, keyed by property name.
+	// Properties that are present are cast using their descriptor, required ones
+	// must be present. Properties not listed are kept as decoded from JSON.
+	Properties map[string]Field `json:"properties,omitempty"`
+
+	// List properties.
+
+	// Delimiter splits the items of a list field. The default value is ",".
+	Delimiter string `json:"delimiter,omitempty"`
+	// ItemType is the type of the items of a list field. The default value is "string".
+	// Supported item types are string, integer, number, boolean, date, datetime and time.
+	ItemType FieldType `json:"itemType,omitempty"`
+
+	// Categorical properties.
+
+	// Categories lists the allowed values of a categorical field.
+	Categories []Category `json:"categories,omitempty"`
+	// CategoriesOrdered indicates whether the categories order is meaningful. If true,
+	// values can be compared using Field.CompareCategories.
+	CategoriesOrdered bool `json:"categoriesOrdered,omitempty"`
+
+	// MissingValuesPlaceholder holds the field-level missingValues declaration. Each
+	// entry is either a string or, as in Table Schema v2, a {"value", "label"} object.
+	MissingValuesPlaceholder interface{} `json:"missingValues,omitempty"`
 	// MissingValues is a map which dictates which string values should be treated as null
-	// values.
+	// values. It is filled from the field-level declaration or, when reading a schema,
+	// from the schema-level one. If nil, the schema-level missing values are used and
+	// then the default [""].
 	MissingValues map[string]struct{} `json:"-"`
+	// MissingValueLabels maps missing values to the label describing why the value is missing.
+	MissingValueLabels map[string]string `json:"-"`
 
 	// Constraints can be used by consumers to list constraints for validating
 	// field values.
 	Constraints Constraints `json:"constraints,omitempty"`
 }
 
-// UnmarshalJSON sets *f to a copy of data. It will respect the default values
-// described at: https://specs.frictionlessdata.io/table-schema/
-func (f *Field) UnmarshalJSON(data []byte) error {
-	// This is neded so it does not call UnmarshalJSON from recursively.
-	type fieldAlias Field
-	u := &fieldAlias{
+// withDefaults returns a field holding the default values described at:
+// https://specs.frictionlessdata.io/table-schema/
+func withDefaults() Field {
+	// Slices are copied, so decoding into the field does not overwrite the defaults.
+	return Field{
 		Type:        defaultFieldType,
 		Format:      defaultFieldFormat,
-		TrueValues:  defaultTrueValues,
-		FalseValues: defaultFalseValues,
+		TrueValues:  append([]string(nil), defaultTrueValues...),
+		FalseValues: append([]string(nil), defaultFalseValues...),
 		DecimalChar: defaultDecimalChar,
 		GroupChar:   defaultGroupChar,
 		BareNumber:  defaultBareNumber,
 	}
-	if err := json.Unmarshal(data, u); err != nil {
+}
+
+// UnmarshalJSON sets *f to a copy of data. It will respect the default values
+// described at: https://specs.frictionlessdata.io/table-schema/
+func (f *Field) UnmarshalJSON(data []byte) error {
+	// This is neded so it does not call UnmarshalJSON from recursively.
+	type fieldAlias Field
+	u := fieldAlias(withDefaults())
+	if err := json.Unmarshal(data, &u); err != nil {
 		return err
 	}
-	*f = Field(*u)
+	*f = Field(u)
 	// Transformation/Validation that should be done at creation time.
+	if f.MissingValuesPlaceholder != nil {
+		values, labels, err := parseMissingValues(f.MissingValuesPlaceholder)
+		if err != nil {
+			return fmt.Errorf("invalid missingValues for field %s: %v", f.Name, err)
+		}
+		f.MissingValues = make(map[string]struct{}, len(values))
+		for _, v := range values {
+			f.MissingValues[v] = struct{}{}
+		}
+		f.MissingValueLabels = labels
+	}
+	return f.compile()
+}
+
+// compile builds the field state derived from its descriptor, which is used
+// by Cast: property names, compiled pattern and raw enum values.
+func (f *Field) compile() error {
+	for k, p := range f.Properties {
+		p.Name = k
+		f.Properties[k] = p
+	}
 	if f.Constraints.Pattern != "" {
 		p, err := regexp.Compile(f.Constraints.Pattern)
 		if err != nil {
@@ -139,26 +237,28 @@ func (f *Field) UnmarshalJSON(data []byte) error {
 		f.Constraints.compiledPattern = p
 	}
 	if f.Constraints.Enum != nil {
-		f.Constraints.rawEnum = make(map[string]struct{})
-		for i := range f.Constraints.Enum {
-			e, err := f.Uncast(f.Constraints.Enum[i])
-			if err != nil {
-				return err
-			}
-			f.Constraints.rawEnum[e] = struct{}{}
+		rawEnum, err := f.compileEnum()
+		if err != nil {
+			return err
 		}
+		f.Constraints.rawEnum = rawEnum
 	}
 	return nil
 }
 
 // Cast casts the passed-in string against field type. Returns an error
 // if the value can not be cast or any field constraint can not be satisfied.
+//
+// Missing values (see Field.MissingValues) are cast to nil. As "" is a missing value
+// unless the field declares its own missing values, Cast("") returns nil even for
+// string fields (or a *RequiredError if the field is required). Declare empty
+// missingValues to cast "" to the empty string.
 func (f *Field) Cast(value string) (interface{}, error) {
-	if f.Constraints.Required {
-		_, ok := f.MissingValues[value]
-		if ok {
-			return nil, fmt.Errorf("%s is required", f.Name)
+	if _, ok := f.MissingValueLabel(value); ok {
+		if f.Constraints.Required {
+			return nil, &RequiredError{Field: f.Name, Value: value}
 		}
+		return nil, nil
 	}
 	var castd interface{}
 	var err error
@@ -166,7 +266,11 @@ func (f *Field) Cast(value string) (interface{}, error) {
 	case IntegerType:
 		castd, err = castInt(f.BareNumber, value, f.Constraints)
 	case StringType:
-		castd, err = castString(f.Format, value, f.Constraints)
+		if f.Format == stringBinary {
+			castd, err = castBinary(value, f.Constraints)
+			break
+		}
+		castd, err = castString(f.Format, value, f.UUIDVersions, f.Constraints)
 	case BooleanType:
 		castd, err = castBoolean(value, f.TrueValues, f.FalseValues)
 	case NumberType:
@@ -174,9 +278,9 @@ func (f *Field) Cast(value string) (interface{}, error) {
 	case DateType:
 		castd, err = castDate(f.Format, value, f.Constraints)
 	case ObjectType:
-		castd, err = castObject(value)
+		castd, err = castObject(value, f.Properties, f.Constraints)
 	case ArrayType:
-		castd, err = castArray(value)
+		castd, err = castArray(value, f.ArrayItem, f.Constraints)
 	case TimeType:
 		castd, err = castTime(f.Format, value, f.Constraints)
 	case YearMonthType:
@@ -186,11 +290,20 @@ func (f *Field) Cast(value string) (interface{}, error) {
 	case DateTimeType:
 		castd, err = castDateTime(value, f.Constraints)
 	case DurationType:
-		castd, err = castDuration(value)
+		castd, err = castDuration(value, f.Constraints)
 	case GeoPointType:
 		castd, err = castGeoPoint(f.Format, value)
 	case AnyType:
 		castd, err = castAny(value)
+	case CategoricalType:
+		castd, err = castCategorical(f, value)
+	case ListType:
+		// Item-level constraints (including enum) are checked by castList.
+		return castList(f, value)
+	default:
+		if c, ok := customCaster(f.Type); ok {
+			castd, err = castCustom(c, f, value)
+		}
 	}
 	if err != nil {
 		return nil, err
@@ -199,7 +312,7 @@ func (f *Field) Cast(value string) (interface{}, error) {
 		return nil, fmt.Errorf("invalid field type: %s", f.Type)
 	}
 	if len(f.Constraints.rawEnum) > 0 {
-		rawValue, err := f.Uncast(castd)
+		rawValue, err := f.enumKey(castd)
 		if err != nil {
 			return nil, err
 		}
@@ -241,11 +354,22 @@ func (f *Field) Uncast(in interface{}) (string, error) {
 	case ObjectType:
 		return uncastObject(inInterface)
 	case StringType:
+		if f.Format == stringBinary {
+			return uncastBinary(inInterface)
+		}
 		_, ok = inInterface.(string)
 	case ArrayType:
 		ok = reflect.TypeOf(inInterface).Kind() == reflect.Slice
 	case AnyType:
 		return uncastAny(in)
+	case ListType:
+		return uncastList(f, inInterface)
+	case CategoricalType:
+		return uncastCategorical(f, inInterface)
+	default:
+		if c, ok := customCaster(f.Type); ok {
+			return c.Uncast(f, in)
+		}
 	}
 	if !ok {
 		return "", fmt.Errorf("can not convert \"%d\" which type is %s to type %s", in, reflect.TypeOf(in), f.Type)
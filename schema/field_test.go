@@ -70,6 +70,8 @@ func TestField_Cast(t *testing.T) {
 	}{
 		{"Integer", "42", Field{Type: IntegerType}, int64(42)},
 		{"String_URI", "http:/frictionlessdata.io", Field{Type: StringType, Format: "uri"}, "http:/frictionlessdata.io"},
+		{"String_UUIDVersions", "017f22e2-79b0-7cc3-98c4-dc0c0c07398f", Field{Type: StringType, Format: "uuid", UUIDVersions: []int{4, 7}}, "017f22e2-79b0-7cc3-98c4-dc0c0c07398f"},
+		{"String_Binary", "aGVsbG8=", Field{Type: StringType, Format: "binary"}, []byte("hello")},
 		{"Boolean_TrueValues", "1", Field{Type: BooleanType, TrueValues: []string{"1"}}, true},
 		{"Boolean_FalseValues", "0", Field{Type: BooleanType, FalseValues: []string{"0"}}, false},
 		{"Number", "42.5", Field{Type: NumberType}, 42.5},
@@ -208,6 +210,56 @@ func TestField_Cast(t *testing.T) {
 	})
 }
 
+func TestField_CastNested(t *testing.T) {
+	t.Run("ArrayItem", func(t *testing.T) {
+		is := is.New(t)
+		var f Field
+		is.NoErr(json.Unmarshal([]byte(`{"name":"tags","type":"array","arrayItem":{"type":"string","constraints":{"pattern":"^[a-z]+$"}},"constraints":{"maxLength":2}}`), &f))
+		got, err := f.Cast(`["foo","bar"]`)
+		is.NoErr(err)
+		is.Equal(got, []interface{}{"foo", "bar"})
+
+		_, err = f.Cast(`["Foo"]`)
+		is.True(err != nil) // item does not match pattern
+		_, err = f.Cast(`["a","b","c"]`)
+		is.True(err != nil) // too many items
+	})
+	t.Run("ObjectProperties", func(t *testing.T) {
+		is := is.New(t)
+		var f Field
+		is.NoErr(json.Unmarshal([]byte(`{"name":"meta","type":"object","properties":{"version":{"type":"integer","constraints":{"required":true}}}}`), &f))
+		is.Equal(f.Properties["version"].Name, "version")
+		is.Equal(f.Properties["version"].TrueValues, defaultTrueValues) // defaults are set on properties.
+
+		got, err := f.Cast(`{"version":"2"}`)
+		is.NoErr(err)
+		is.Equal(got, map[string]interface{}{"version": int64(2)})
+
+		_, err = f.Cast(`{"name":"foo"}`)
+		is.True(err != nil) // missing required property
+	})
+	t.Run("ObjectEnum", func(t *testing.T) {
+		is := is.New(t)
+		var f Field
+		is.NoErr(json.Unmarshal([]byte(`{"name":"meta","type":"object","properties":{"d":{"type":"date"}},"constraints":{"enum":[{"d":"2020-01-02"},{"d":"2021-01-02"}]}}`), &f))
+		_, err := f.Cast(`{"d":"2021-01-02"}`)
+		is.NoErr(err)
+		_, err = f.Cast(`{"d":"2022-01-02"}`)
+		is.True(err != nil)
+	})
+	t.Run("ArrayEnum", func(t *testing.T) {
+		is := is.New(t)
+		var f Field
+		is.NoErr(json.Unmarshal([]byte(`{"name":"a","type":"array","arrayItem":{"type":"integer"},"constraints":{"enum":[[1,2],["3"]]}}`), &f))
+		_, err := f.Cast(`["1",2]`)
+		is.NoErr(err)
+		_, err = f.Cast(`[3]`)
+		is.NoErr(err)
+		_, err = f.Cast(`[1]`)
+		is.True(err != nil)
+	})
+}
+
 func TestUnmarshalJSON_InvalidField(t *testing.T) {
 	is := is.New(t)
 	var f Field
@@ -237,6 +289,7 @@ func TestField_Uncast(t *testing.T) {
 			{"Duration", Field{Type: DurationType}, 1 * time.Second, "P0Y0M0DT1S"},
 			{"GeoPoint", Field{Type: GeoPointType}, "10,10", "10,10"},
 			{"String", Field{Type: StringType}, "foo", "foo"},
+			{"String_Binary", Field{Type: StringType, Format: "binary"}, []byte("hello"), "aGVsbG8="},
 			{"Array", Field{Type: ArrayType}, []string{"foo"}, "[foo]"},
 			{"Date", Field{Type: DateType}, time.Unix(1, 0), "1970-01-01T00:00:01Z"},
 			{"Year", Field{Type: YearType}, time.Unix(1, 0), "1970-01-01T00:00:01Z"},
@@ -264,6 +317,7 @@ func TestField_Uncast(t *testing.T) {
 			{"StringToIntCast", Field{Type: IntegerType}, "1.5"},
 			{"StringToNumberCast", Field{Type: NumberType}, "1.5"},
 			{"InvalidType", Field{Type: "Boo"}, "1"},
+			{"InvalidBinary", Field{Type: StringType, Format: "binary"}, 10},
 		}
 		for _, d := range data {
 			t.Run(d.desc, func(t *testing.T) {
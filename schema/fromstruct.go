@@ -0,0 +1,235 @@
+package schema
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Tag names used by FromStruct.
+const (
+	tableformatTag      = "tableformat"
+	tabletypeTag        = "tabletype"
+	tabletitleTag       = "tabletitle"
+	tabledescriptionTag = "tabledescription"
+	tableconstraintsTag = "tableconstraints"
+)
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+	geoPointType = reflect.TypeOf(GeoPoint{})
+	bytesType    = reflect.TypeOf([]byte(nil))
+)
+
+// FromStruct creates a schema describing the passed-in struct (or pointer to struct).
+// Like Schema.CastRow, exported fields are mapped to schema fields using the tableheader
+// tag or the field name, and nested structs are flattened. Fields tagged with
+// tableheader:"-" are skipped.
+//
+// Field types are derived from Go types: time.Time is mapped to datetime,
+// time.Duration to duration, GeoPoint to geopoint, bool to boolean, integers to
+// integer, floats to number, []byte to string with binary format, slices of
+// strings, integers, floats, booleans and time.Time to list, other slices to array,
+// maps to object and other types to any. The tabletype tag overrides the derived type.
+//
+// Fields are required, unless they are pointers, Null or sql.Null* values.
+//
+// Other properties are set using the tableformat, tabletitle, tabledescription and
+// tableconstraints tags. The tableconstraints tag holds a comma-separated list of
+// constraints, like in:
+//
+//	type Person struct {
+//	  Name  string  `tableheader:"name" tableconstraints:"unique,minLength=1,pattern=[A-Z].*"`
+//	  Age   *int64  `tableheader:"age" tableconstraints:"minimum=0,maximum=150"`
+//	  Role  string  `tableheader:"role" tableconstraints:"enum=admin|user"`
+//	}
+//
+// Commas within values must be escaped as "\,". Enum values are separated by "|".
+func FromStruct(v interface{}) (*Schema, error) {
+	t := reflect.TypeOf(v)
+	if t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("can only derive schemas from structs or pointer to structs")
+	}
+	fields, err := getStructFields(reflect.New(t).Interface())
+	if err != nil {
+		return nil, err
+	}
+	b := New()
+	for _, f := range fields {
+		name, ok := f.Tag.Lookup(tableheaderTag)
+		if !ok {
+			name = f.Name
+		}
+		if name == "-" {
+			continue
+		}
+		t, opts, err := structFieldOpts(f.StructField)
+		if err != nil {
+			return nil, fmt.Errorf("invalid struct field %s: %v", f.Name, err)
+		}
+		b.Field(name, t, opts...)
+	}
+	return b.Build()
+}
+
+// structFieldOpts returns the type and options used to create the schema field
+// describing the struct field.
+func structFieldOpts(f reflect.StructField) (FieldType, []FieldOpts, error) {
+	t, required := f.Type, true
+	if t.Kind() == reflect.Ptr {
+		t, required = t.Elem(), false
+	}
+	if isNullable(t) {
+		t, required = nullableElem(t), false
+	}
+	fieldType, format, itemType := goFieldType(t)
+	if tag, ok := f.Tag.Lookup(tabletypeTag); ok {
+		fieldType = FieldType(tag)
+	}
+	var opts []FieldOpts
+	if itemType != "" && fieldType == ListType {
+		opts = append(opts, ItemType(itemType))
+	}
+	if tag, ok := f.Tag.Lookup(tableformatTag); ok {
+		format = tag
+	}
+	if format != "" {
+		opts = append(opts, Format(format))
+	}
+	if tag, ok := f.Tag.Lookup(tabletitleTag); ok {
+		opts = append(opts, Title(tag))
+	}
+	if tag, ok := f.Tag.Lookup(tabledescriptionTag); ok {
+		opts = append(opts, Description(tag))
+	}
+	if required {
+		opts = append(opts, Required())
+	}
+	c, err := parseConstraintsTag(f.Tag.Get(tableconstraintsTag))
+	if err != nil {
+		return "", nil, err
+	}
+	return fieldType, append(opts, c...), nil
+}
+
+// nullableElem returns the type of the value held by nullable types like
+// sql.NullInt64, which is the type of their first field. It returns the
+// interface{} type if it is not known.
+func nullableElem(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Struct && t.NumField() == 2 {
+		if _, ok := t.FieldByName("Valid"); ok && t.Field(0).Name != "Valid" {
+			return t.Field(0).Type
+		}
+	}
+	return reflect.TypeOf((*interface{})(nil)).Elem()
+}
+
+// goFieldType returns the field type, format and list item type which describe
+// values of the Go type.
+func goFieldType(t reflect.Type) (FieldType, string, FieldType) {
+	switch t {
+	case timeType:
+		return DateTimeType, "", ""
+	case durationType:
+		return DurationType, "", ""
+	case geoPointType:
+		return GeoPointType, "", ""
+	case bytesType:
+		return StringType, stringBinary, ""
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return StringType, "", ""
+	case reflect.Bool:
+		return BooleanType, "", ""
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return IntegerType, "", ""
+	case reflect.Float32, reflect.Float64:
+		return NumberType, "", ""
+	case reflect.Map:
+		return ObjectType, "", ""
+	case reflect.Slice:
+		if item, format, _ := goFieldType(t.Elem()); format == "" {
+			if _, ok := listItemTypes[item]; ok {
+				return ListType, "", item
+			}
+		}
+		return ArrayType, "", ""
+	}
+	return AnyType, "", ""
+}
+
+// parseConstraintsTag parses the value of the tableconstraints tag.
+func parseConstraintsTag(tag string) ([]FieldOpts, error) {
+	var opts []FieldOpts
+	for _, entry := range splitEscaped(tag, ',') {
+		if entry == "" {
+			continue
+		}
+		name, value := entry, ""
+		if i := strings.Index(entry, "="); i >= 0 {
+			name, value = entry[:i], entry[i+1:]
+		}
+		switch name {
+		case requiredConstraint:
+			opts = append(opts, Required())
+		case uniqueConstraint:
+			opts = append(opts, Unique())
+		case minimumConstraint:
+			opts = append(opts, Min(value))
+		case maximumConstraint:
+			opts = append(opts, Max(value))
+		case exclusiveMinimumConstraint:
+			opts = append(opts, ExclusiveMin(value))
+		case exclusiveMaximumConstraint:
+			opts = append(opts, ExclusiveMax(value))
+		case minLengthConstraint, maxLengthConstraint:
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s constraint:%q", name, value)
+			}
+			if name == minLengthConstraint {
+				opts = append(opts, MinLength(n))
+			} else {
+				opts = append(opts, MaxLength(n))
+			}
+		case patternConstraint:
+			opts = append(opts, Pattern(value))
+		case enumConstraint:
+			var values []interface{}
+			for _, v := range strings.Split(value, "|") {
+				values = append(values, v)
+			}
+			opts = append(opts, Enum(values...))
+		default:
+			return nil, fmt.Errorf("unknown constraint:%q", name)
+		}
+	}
+	return opts, nil
+}
+
+// splitEscaped splits s around sep, unless it is preceded by a backslash.
+func splitEscaped(s string, sep byte) []string {
+	var ret []string
+	var cur strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch {
+		case s[i] == '\\' && i+1 < len(s) && s[i+1] == sep:
+			cur.WriteByte(sep)
+			i++
+		case s[i] == sep:
+			ret = append(ret, cur.String())
+			cur.Reset()
+		default:
+			cur.WriteByte(s[i])
+		}
+	}
+	return append(ret, cur.String())
+}
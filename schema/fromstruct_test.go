@@ -0,0 +1,147 @@
+package schema
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestFromStruct(t *testing.T) {
+	t.Run("Types", func(t *testing.T) {
+		is := is.New(t)
+		type inner struct {
+			City  string
+			Where GeoPoint
+		}
+		type rec struct {
+			Name     string
+			Age      *int
+			Height   float32
+			Active   bool
+			Born     time.Time
+			Elapsed  time.Duration
+			Avatar   []byte
+			Tags     []string
+			Scores   []int64
+			Raw      []interface{}
+			Attrs    map[string]interface{}
+			Nickname sql.NullString
+			Visits   sql.NullInt64
+			Last     sql.NullTime
+			Misc     Null
+			Other    interface{}
+			Inner    *inner
+			private  int
+		}
+		s, err := FromStruct(&rec{})
+		is.NoErr(err)
+		want := []struct {
+			name     string
+			t        FieldType
+			format   string
+			required bool
+		}{
+			{"Name", StringType, defaultFieldFormat, true},
+			{"Age", IntegerType, defaultFieldFormat, false},
+			{"Height", NumberType, defaultFieldFormat, true},
+			{"Active", BooleanType, defaultFieldFormat, true},
+			{"Born", DateTimeType, defaultFieldFormat, true},
+			{"Elapsed", DurationType, defaultFieldFormat, true},
+			{"Avatar", StringType, stringBinary, true},
+			{"Tags", ListType, defaultFieldFormat, true},
+			{"Scores", ListType, defaultFieldFormat, true},
+			{"Raw", ArrayType, defaultFieldFormat, true},
+			{"Attrs", ObjectType, defaultFieldFormat, true},
+			{"Nickname", StringType, defaultFieldFormat, false},
+			{"Visits", IntegerType, defaultFieldFormat, false},
+			{"Last", DateTimeType, defaultFieldFormat, false},
+			{"Misc", AnyType, defaultFieldFormat, false},
+			{"Other", AnyType, defaultFieldFormat, true},
+			{"City", StringType, defaultFieldFormat, true},
+			{"Where", GeoPointType, defaultFieldFormat, true},
+		}
+		is.Equal(len(s.Fields), len(want))
+		for i, f := range s.Fields {
+			is.Equal(f.Name, want[i].name)
+			is.Equal(f.Type, want[i].t)
+			is.Equal(f.Format, want[i].format)
+			is.Equal(f.Constraints.Required, want[i].required)
+		}
+		is.Equal(s.Fields[7].ItemType, StringType)
+		is.Equal(s.Fields[8].ItemType, IntegerType)
+	})
+	t.Run("Tags", func(t *testing.T) {
+		is := is.New(t)
+		type rec struct {
+			ID      int64     `tableheader:"id" tabletitle:"Identifier" tabledescription:"The record ID" tableconstraints:"unique,minimum=1"`
+			Email   string    `tableheader:"email" tableformat:"email" tableconstraints:"maxLength=100,pattern=.{1\\,100}"`
+			Day     time.Time `tableheader:"day" tabletype:"date" tableformat:"%d/%m/%Y"`
+			Role    *string   `tableheader:"role" tableconstraints:"enum=admin|user"`
+			Ignored string    `tableheader:"-"`
+		}
+		s, err := FromStruct(rec{})
+		is.NoErr(err)
+
+		want, err := New().
+			Field("id", IntegerType, Title("Identifier"), Description("The record ID"), Required(), Unique(), Min(1)).
+			Field("email", StringType, Format("email"), Required(), MaxLength(100), Pattern(".{1,100}")).
+			Field("day", DateType, Format("%d/%m/%Y"), Required()).
+			Field("role", StringType, Enum("admin", "user")).
+			Build()
+		is.NoErr(err)
+		is.True(reflect.DeepEqual(s, want))
+
+		// The schema casts rows into the struct.
+		var r rec
+		is.NoErr(s.CastRow([]string{"1", "foo@bar.com", "02/01/2015", ""}, &r))
+		is.Equal(r, rec{ID: 1, Email: "foo@bar.com", Day: time.Date(2015, 1, 2, 0, 0, 0, 0, time.UTC)})
+		is.True(s.CastRow([]string{"1", "foo@bar.com", "02/01/2015", "root"}, &r) != nil)
+		is.True(s.CastRow([]string{"0", "foo@bar.com", "02/01/2015", ""}, &r) != nil)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		data := []struct {
+			Desc string
+			In   interface{}
+		}{
+			{"Nil", nil},
+			{"NotStruct", 1},
+			{"UnknownConstraint", struct {
+				A int `tableconstraints:"max=1"`
+			}{}},
+			{"InvalidLength", struct {
+				A string `tableconstraints:"maxLength=a"`
+			}{}},
+			{"InvalidBound", struct {
+				A int `tableconstraints:"minimum=a"`
+			}{}},
+			{"DuplicateName", struct {
+				A int
+				B int `tableheader:"A"`
+			}{}},
+			{"InvalidType", struct {
+				A int `tabletype:"integr"`
+			}{}},
+		}
+		for _, d := range data {
+			t.Run(d.Desc, func(t *testing.T) {
+				is := is.New(t)
+				_, err := FromStruct(d.In)
+				is.True(err != nil)
+			})
+		}
+	})
+}
+
+func TestSchema_CastRowGeoPoint(t *testing.T) {
+	is := is.New(t)
+	s, err := FromStruct(struct{ Where *GeoPoint }{})
+	is.NoErr(err)
+	var r struct {
+		Where *GeoPoint
+	}
+	is.NoErr(s.CastRow([]string{"1,2"}, &r))
+	is.Equal(*r.Where, GeoPoint{Lon: 1, Lat: 2})
+}
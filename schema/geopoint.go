@@ -73,6 +73,9 @@ func applyGeoPointRegexp(r *regexp.Regexp, value string) (GeoPoint, error) {
 func uncastGeoPoint(format string, gp interface{}) (string, error) {
 	switch format {
 	case "", defaultFieldFormat:
+		if p, ok := gp.(GeoPoint); ok {
+			return fmt.Sprintf("%v,%v", p.Lon, p.Lat), nil
+		}
 		value, ok := gp.(string)
 		if ok {
 			_, err := applyGeoPointRegexp(geoPointDefaultRegexp, value)
@@ -81,8 +84,11 @@ func uncastGeoPoint(format string, gp interface{}) (string, error) {
 			}
 			return value, nil
 		}
-		return "", fmt.Errorf("invalid object type to uncast to geopoint dfault format. want:string got:%v", reflect.TypeOf(gp).String())
+		return "", fmt.Errorf("invalid object type to uncast to geopoint dfault format. want:string or schema.GeoPoint got:%v", reflect.TypeOf(gp).String())
 	case GeoPointArrayFormat:
+		if p, ok := gp.(GeoPoint); ok {
+			return fmt.Sprintf("[%v,%v]", p.Lon, p.Lat), nil
+		}
 		value, ok := gp.(string)
 		if ok {
 			_, err := applyGeoPointRegexp(geoPointArrayRegexp, value)
@@ -91,7 +97,7 @@ func uncastGeoPoint(format string, gp interface{}) (string, error) {
 			}
 			return value, nil
 		}
-		return "", fmt.Errorf("invalid object type to uncast to geopoint %s format. want:string got:%v", GeoPointArrayFormat, reflect.TypeOf(gp).String())
+		return "", fmt.Errorf("invalid object type to uncast to geopoint %s format. want:string or schema.GeoPoint got:%v", GeoPointArrayFormat, reflect.TypeOf(gp).String())
 	case GeoPointObjectFormat:
 		value, ok := gp.(GeoPoint)
 		if ok {
@@ -67,6 +67,8 @@ func TestUncastGeoPoint(t *testing.T) {
 			{"GeoPointObject", GeoPointObjectFormat, GeoPoint{10, 10}, "{Lon:10 Lat:10}"},
 			{"GeoPointArray", GeoPointArrayFormat, "[10,10]", "[10,10]"},
 			{"GeoPointDefault", defaultFieldFormat, "10,10", "10,10"},
+			{"GeoPointArray_GeoPoint", GeoPointArrayFormat, GeoPoint{10, 10.5}, "[10,10.5]"},
+			{"GeoPointDefault_GeoPoint", defaultFieldFormat, GeoPoint{10, 10.5}, "10,10.5"},
 		}
 		for _, d := range data {
 			t.Run(d.desc, func(t *testing.T) {
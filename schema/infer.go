@@ -2,6 +2,7 @@ package schema
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/frictionlessdata/tableschema-go/table"
 )
@@ -57,38 +58,77 @@ const (
 // Infer infers a schema from a slice of the tabular data. For columns that contain
 // cells that can inferred as different types, the most popular type is set as the field
 // type. For instance, a column with values 10.1, 10, 10 will inferred as being of type
-// "integer".
+// "integer". Ties are broken by the precedence order (see WithPriorityOrder) and the
+// most popular type can be widened to meet a confidence threshold (see WithConfidence).
+//
+// Missing values (see WithMissingValues) do not take part in the type inference.
 func Infer(tab table.Table, opts ...InferOpts) (*Schema, error) {
-	cfg := &inferConfig{}
-	for _, opt := range opts {
-		if err := opt(cfg); err != nil {
-			return nil, err
-		}
+	return inferTable(tab, false, opts)
+}
+
+// InferImplicitCasting uses a implicit casting for infering the type of columns
+// that have cells of diference types. For instance, a column with values 10.1, 10, 10
+// will inferred as being of type "number" ("integer" can be implicitly cast to "number").
+// The inferred type is the narrowest type all cells can be implicitly cast to.
+//
+// For medium to big tables, this method is faster than the Infer.
+func InferImplicitCasting(tab table.Table, opts ...InferOpts) (*Schema, error) {
+	return inferTable(tab, true, opts)
+}
+
+func inferTable(tab table.Table, implicitCasting bool, opts []InferOpts) (*Schema, error) {
+	cfg, err := newInferConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+	return inferWithConfig(tab, implicitCasting, cfg)
+}
+
+func inferWithConfig(tab table.Table, implicitCasting bool, cfg *inferConfig) (*Schema, error) {
+	if cfg.streaming {
+		return inferStream(tab, implicitCasting, cfg)
 	}
 	s, err := sample(tab, cfg)
 	if err != nil {
 		return nil, err
 	}
-	precedenceOrder := orderedTypes
-	if len(cfg.precedenceOrder) > 0 {
-		precedenceOrder = cfg.precedenceOrder
+	var sch *Schema
+	if implicitCasting {
+		sch, err = inferImplicitCasting(tab.Headers(), s, cfg)
+	} else {
+		sch, err = infer(tab.Headers(), s, cfg)
 	}
-	return infer(tab.Headers(), s, precedenceOrder)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.formats {
+		inferFormats(sch, s, cfg)
+	}
+	if cfg.maxCategories > 0 {
+		inferCategorical(sch, s, cfg)
+	}
+	if cfg.constraints != nil {
+		if err := inferConstraints(sch, s, cfg); err != nil {
+			return nil, err
+		}
+	}
+	return sch, nil
 }
 
-func sample(tab table.Table, cfg *inferConfig) ([][]string, error) {
-	limit := defaultMaxNumRowsInfer
-	if cfg.sampleLimit != 0 {
-		limit = cfg.sampleLimit
-	}
-	iter, err := tab.Iter()
+// inferStream infers the types of the columns of the table, row by row, without
+// keeping the rows in memory.
+func inferStream(tab table.Table, implicitCasting bool, cfg *inferConfig) (*Schema, error) {
+	iter, err := cfg.iter(tab)
 	if err != nil {
 		return nil, err
 	}
-	var t [][]string
+	defer iter.Close()
+	limit := cfg.limit()
+	ti := newTypeInference(tab.Headers(), cfg, implicitCasting)
 	for count := 0; iter.Next(); count++ {
-		t = append(t, iter.Row())
-		// A negative limit will continue to sample the entire table.
+		if err := ti.add(iter.Row()); err != nil {
+			return nil, err
+		}
 		if limit > 0 && count == limit-1 {
 			break
 		}
@@ -96,93 +136,185 @@ func sample(tab table.Table, cfg *inferConfig) ([][]string, error) {
 	if iter.Err() != nil {
 		return nil, iter.Err()
 	}
-	return t, nil
+	return ti.schema(), nil
+}
+
+func infer(headers []string, table [][]string, cfg *inferConfig) (*Schema, error) {
+	ti := newTypeInference(headers, cfg, false)
+	for _, row := range table {
+		if err := ti.add(row); err != nil {
+			return nil, err
+		}
+	}
+	return ti.schema(), nil
+}
+
+func inferImplicitCasting(headers []string, table [][]string, cfg *inferConfig) (*Schema, error) {
+	ti := newTypeInference(headers, cfg, true)
+	for _, row := range table {
+		if err := ti.add(row); err != nil {
+			return nil, err
+		}
+	}
+	return ti.schema(), nil
+}
+
+// typeInference holds the per-column counters used to infer the column types, which
+// are updated row by row.
+type typeInference struct {
+	headers         []string
+	cfg             *inferConfig
+	missing         map[string]struct{}
+	precedenceOrder []FieldType
+	// implicitCasting makes the inferred type the join of the cell types, instead of
+	// the most popular one.
+	implicitCasting bool
+	columns         []columnTypes
+	// types holds the types inferred using implicit casting.
+	types []FieldType
+	rows  int
+}
+
+func newTypeInference(headers []string, cfg *inferConfig, implicitCasting bool) *typeInference {
+	order := inferableTypes()
+	if len(cfg.precedenceOrder) > 0 {
+		order = cfg.precedenceOrder
+	}
+	return &typeInference{
+		headers:         headers,
+		cfg:             cfg,
+		missing:         cfg.missingValueSet(),
+		precedenceOrder: order,
+		implicitCasting: implicitCasting,
+		columns:         newColumnTypes(len(headers)),
+		types:           make([]FieldType, len(headers)),
+	}
 }
 
-func infer(headers []string, table [][]string, precedenceOrder []FieldType) (*Schema, error) {
-	inferredTypes := make([]map[FieldType]int, len(headers))
-	for rowID := range table {
-		row := table[rowID]
-		// TODO(danielfireman): the python version does some normalization on
-		// the number of columns and headers. Need to look closer at this.
-		if len(headers) != len(row) {
-			return nil, fmt.Errorf("data is not tabular. headers:%v row[%d]:%v", headers, rowID, row)
+func (ti *typeInference) add(row []string) error {
+	rowID := ti.rows
+	ti.rows++
+	// Ragged rows which are not handled by the WithRaggedRows policy.
+	if len(ti.headers) != len(row) {
+		return fmt.Errorf("data is not tabular. headers:%v row[%d]:%v", ti.headers, rowID, row)
+	}
+	for cellIndex, cell := range row {
+		if _, ok := ti.missing[cell]; ok {
+			ti.columns[cellIndex].nulls++
+			continue
+		}
+		if !ti.implicitCasting {
+			ti.columns[cellIndex].add(cell, findType(cell, ti.precedenceOrder))
+			continue
+		}
+		if ti.cfg.report != nil {
+			ti.columns[cellIndex].add(cell, findType(cell, ti.precedenceOrder))
 		}
-		for cellIndex, cell := range row {
-			if inferredTypes[cellIndex] == nil {
-				inferredTypes[cellIndex] = make(map[FieldType]int)
+		current := ti.types[cellIndex]
+		switch current {
+		case "":
+			ti.types[cellIndex] = findType(cell, ti.precedenceOrder)
+		case StringType:
+		default:
+			t := findType(cell, implicitCast[current])
+			if t == StringType {
+				// The cell might be of a type that joins the current one
+				// into a type wider than current, but narrower than string.
+				t = joinTypes(current, findType(cell, ti.precedenceOrder))
 			}
-			t := findType(cell, precedenceOrder)
-			inferredTypes[cellIndex][t]++
+			ti.types[cellIndex] = t
 		}
 	}
+	return nil
+}
+
+func (ti *typeInference) schema() *Schema {
 	schema := Schema{}
-	for index := range headers {
+	for index := range ti.headers {
+		t := ti.types[index]
+		if !ti.implicitCasting {
+			t = ti.columns[index].vote(ti.precedenceOrder, ti.cfg.confidence)
+		}
+		if t == "" {
+			t = defaultFieldType
+		}
 		schema.Fields = append(schema.Fields,
 			Field{
-				Name:   headers[index],
-				Type:   defaultFieldType,
+				Name:   ti.headers[index],
+				Type:   t,
 				Format: defaultFieldFormat,
 			})
-		count := 0
-		for t, c := range inferredTypes[index] {
-			if c > count {
-				f := &schema.Fields[index]
-				f.Type = t
-				count = c
-			}
-		}
 	}
-	return &schema, nil
+	schema.RaggedRows = ti.cfg.ragged
+	ti.cfg.applyMissingValues(&schema, ti.columns)
+	ti.cfg.fillReport(&schema, ti.columns)
+	return &schema
 }
 
-// InferImplicitCasting uses a implicit casting for infering the type of columns
-// that have cells of diference types. For instance, a column with values 10.1, 10, 10
-// will inferred as being of type "number" ("integer" can be implicitly cast to "number").
-//
-// For medium to big tables, this method is faster than the Infer.
-func InferImplicitCasting(tab table.Table, opts ...InferOpts) (*Schema, error) {
-	cfg := &inferConfig{}
-	for _, opt := range opts {
-		if err := opt(cfg); err != nil {
-			return nil, err
+// joinTypes returns the narrowest type both types can be implicitly cast to, possibly
+// through other types (for instance, yearmonth is cast to datetime through date).
+func joinTypes(a, b FieldType) FieldType {
+	if a == b {
+		return a
+	}
+	for _, t := range orderedTypes {
+		if widensTo(a, t) && widensTo(b, t) {
+			return t
 		}
 	}
-	s, err := sample(tab, cfg)
-	if err != nil {
-		return nil, err
+	return StringType
+}
+
+// widensTo returns true if values of type from can be implicitly cast to type to,
+// possibly through other types.
+func widensTo(from, to FieldType) bool {
+	if from == to {
+		return true
+	}
+	for _, t := range implicitCast[from] {
+		if t != from && widensTo(t, to) {
+			return true
+		}
 	}
-	return inferImplicitCasting(tab.Headers(), s)
+	return false
 }
 
-func inferImplicitCasting(headers []string, table [][]string) (*Schema, error) {
-	inferredTypes := make([]FieldType, len(headers))
-	for rowID := range table {
-		row := table[rowID]
-		// TODO(danielfireman): the python version does some normalization on
-		// the number of columns and headers. Need to look closer at this.
-		if len(headers) != len(row) {
-			return nil, fmt.Errorf("data is not tabular. headers:%v row[%d]:%v", headers, rowID, row)
+// inferCategorical turns string fields into categorical fields when the column
+// has at most maxCategories distinct values and at least one of them repeats.
+// Categories are sorted, so the result does not depend on the rows order.
+func inferCategorical(s *Schema, table [][]string, cfg *inferConfig) {
+	missing := cfg.missingValueSet()
+	maxCategories := cfg.maxCategories
+	for index := range s.Fields {
+		f := &s.Fields[index]
+		if f.Type != StringType || f.Format != defaultFieldFormat {
+			continue
 		}
-		for cellIndex, cell := range row {
-			if inferredTypes[cellIndex] == "" {
-				t := findType(cell, orderedTypes)
-				inferredTypes[cellIndex] = t
-			} else {
-				inferredTypes[cellIndex] = findType(cell, implicitCast[inferredTypes[cellIndex]])
+		distinct := make(map[string]struct{})
+		count := 0
+		for _, row := range table {
+			if _, ok := missing[row[index]]; ok {
+				continue
+			}
+			count++
+			distinct[row[index]] = struct{}{}
+			if len(distinct) > maxCategories {
+				break
 			}
 		}
+		if len(distinct) == 0 || len(distinct) > maxCategories || len(distinct) == count {
+			continue
+		}
+		values := make([]string, 0, len(distinct))
+		for v := range distinct {
+			values = append(values, v)
+		}
+		sort.Strings(values)
+		f.Type = CategoricalType
+		for _, v := range values {
+			f.Categories = append(f.Categories, Category{Value: v})
+		}
 	}
-	schema := Schema{}
-	for index := range headers {
-		schema.Fields = append(schema.Fields,
-			Field{
-				Name:   headers[index],
-				Type:   inferredTypes[index],
-				Format: defaultFieldFormat,
-			})
-	}
-	return &schema, nil
 }
 
 func findType(value string, checkOrder []FieldType) FieldType {
@@ -205,11 +337,11 @@ func findType(value string, checkOrder []FieldType) FieldType {
 				return DateType
 			}
 		case ArrayType:
-			if _, err := castArray(value); err == nil {
+			if _, err := castArray(value, nil, noConstraints); err == nil {
 				return ArrayType
 			}
 		case ObjectType:
-			if _, err := castObject(value); err == nil {
+			if _, err := castObject(value, nil, noConstraints); err == nil {
 				return ObjectType
 			}
 		case TimeType:
@@ -229,13 +361,17 @@ func findType(value string, checkOrder []FieldType) FieldType {
 				return DateTimeType
 			}
 		case DurationType:
-			if _, err := castDuration(value); err == nil {
+			if _, err := castDuration(value, noConstraints); err == nil {
 				return DurationType
 			}
 		case GeoPointType:
 			if _, err := castGeoPoint(defaultFieldFormat, value); err == nil {
 				return GeoPointType
 			}
+		default:
+			if detectCustomType(t, value) {
+				return t
+			}
 		}
 	}
 	return StringType
@@ -247,6 +383,60 @@ type InferOpts func(c *inferConfig) error
 type inferConfig struct {
 	sampleLimit     int
 	precedenceOrder []FieldType
+	maxCategories   int
+	missingValues   []string
+	constraints     *ConstraintInference
+	formats         bool
+	confidence      float64
+	report          *InferReport
+	sampling        sampling
+	seed            int64
+	streaming       bool
+	ragged          RaggedRows
+	workers         int
+}
+
+func newInferConfig(opts []InferOpts) (*inferConfig, error) {
+	cfg := &inferConfig{}
+	for _, opt := range opts {
+		if err := opt(cfg); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.streaming && (cfg.formats || cfg.maxCategories > 0 || cfg.constraints != nil) {
+		return nil, fmt.Errorf("streaming inference can not be combined with format, categorical or constraint inference")
+	}
+	if cfg.streaming && cfg.sampling != sampleHead {
+		return nil, fmt.Errorf("streaming inference can not be combined with reservoir or stratified sampling")
+	}
+	return cfg, nil
+}
+
+// missingValueSet returns the values that represent missing cells, which default to
+// the empty string.
+func (c *inferConfig) missingValueSet() map[string]struct{} {
+	values := c.missingValues
+	if values == nil {
+		values = []string{defaultMissingValue}
+	}
+	set := make(map[string]struct{}, len(values))
+	for _, v := range values {
+		set[v] = struct{}{}
+	}
+	return set
+}
+
+// applyMissingValues records the declared missing values in the inferred schema and
+// marks as required the fields in which no missing value was seen.
+func (c *inferConfig) applyMissingValues(s *Schema, columns []columnTypes) {
+	if c.missingValues == nil {
+		return
+	}
+	s.MissingValues = append([]string(nil), c.missingValues...)
+	for i := range s.Fields {
+		s.Fields[i].Constraints.Required = columns[i].nulls == 0
+	}
+	s.propagateMissingValues()
 }
 
 // SampleLimit specifies the maximum number of rows to sample for inference.
@@ -258,9 +448,38 @@ func SampleLimit(limit int) InferOpts {
 }
 
 // WithPriorityOrder allows users to specify the priority order of types used to infer fields.
+// It applies to Infer and InferImplicitCasting, and may list registered custom types
+// (see RegisterType).
 func WithPriorityOrder(precedendeOrder []FieldType) InferOpts {
 	return func(c *inferConfig) error {
 		c.precedenceOrder = precedendeOrder
 		return nil
 	}
 }
+
+// WithMissingValues declares the values that represent missing cells. Missing cells do
+// not take part in the type inference, so a numeric column with a few "N/A" cells is
+// still inferred as numeric. The values are recorded in the inferred schema and fields
+// in which no missing value was seen are marked as required.
+//
+// When this option is not passed, empty strings are excluded from the type inference,
+// but the inferred schema does not declare missing values nor required fields.
+func WithMissingValues(values ...string) InferOpts {
+	return func(c *inferConfig) error {
+		c.missingValues = append([]string{}, values...)
+		return nil
+	}
+}
+
+// WithCategoricalInference makes inference propose "categorical" fields for
+// string columns that have at most maxCategories distinct (non-empty) values,
+// as long as at least one value repeats.
+func WithCategoricalInference(maxCategories int) InferOpts {
+	return func(c *inferConfig) error {
+		if maxCategories <= 0 {
+			return fmt.Errorf("maximum number of categories must be positive, got:%d", maxCategories)
+		}
+		c.maxCategories = maxCategories
+		return nil
+	}
+}
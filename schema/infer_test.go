@@ -156,7 +156,7 @@ func TestInfer(t *testing.T) {
 	for _, d := range data {
 		t.Run(d.desc, func(t *testing.T) {
 			is := is.New(t)
-			s, err := infer(d.headers, d.table, orderedTypes)
+			s, err := infer(d.headers, d.table, &inferConfig{})
 			is.NoErr(err)
 
 			sort.Sort(s.Fields)
@@ -175,7 +175,7 @@ func TestInfer(t *testing.T) {
 		for _, d := range data {
 			t.Run(d.desc, func(t *testing.T) {
 				is := is.New(t)
-				_, err := infer(d.headers, d.table, orderedTypes)
+				_, err := infer(d.headers, d.table, &inferConfig{})
 				is.True(err != nil)
 			})
 		}
@@ -222,7 +222,7 @@ func TestInferImplicitCasting(t *testing.T) {
 	for _, d := range data {
 		t.Run(d.desc, func(t *testing.T) {
 			is := is.New(t)
-			s, err := inferImplicitCasting(d.headers, d.table)
+			s, err := inferImplicitCasting(d.headers, d.table, &inferConfig{})
 			is.NoErr(err)
 
 			sort.Sort(s.Fields)
@@ -241,13 +241,107 @@ func TestInferImplicitCasting(t *testing.T) {
 		for _, d := range data {
 			t.Run(d.desc, func(t *testing.T) {
 				is := is.New(t)
-				_, err := inferImplicitCasting(d.headers, d.table)
+				_, err := inferImplicitCasting(d.headers, d.table, &inferConfig{})
 				is.True(err != nil)
 			})
 		}
 	})
 }
 
+func TestInferCategorical(t *testing.T) {
+	tab := table.FromSlices(
+		[]string{"Name", "Answer", "Score"},
+		[][]string{
+			{"Foo", "yes", "1"},
+			{"Bar", "no", "2"},
+			{"Bez", "yes", "3"},
+			{"Boo", "", "3"},
+		})
+	t.Run("Infer", func(t *testing.T) {
+		is := is.New(t)
+		s, err := Infer(tab, WithCategoricalInference(2))
+		is.NoErr(err)
+		is.Equal(s.Fields[0].Type, StringType) // all values distinct
+		is.Equal(s.Fields[1].Type, CategoricalType)
+		is.Equal(s.Fields[1].Categories, []Category{{Value: "no"}, {Value: "yes"}})
+		is.Equal(s.Fields[2].Type, IntegerType) // only strings are proposed
+	})
+	t.Run("InferImplicitCasting", func(t *testing.T) {
+		is := is.New(t)
+		s, err := InferImplicitCasting(tab, WithCategoricalInference(2))
+		is.NoErr(err)
+		is.Equal(s.Fields[1].Type, CategoricalType)
+	})
+	t.Run("TooManyValues", func(t *testing.T) {
+		is := is.New(t)
+		s, err := Infer(tab, WithCategoricalInference(1))
+		is.NoErr(err)
+		is.Equal(s.Fields[1].Type, StringType)
+	})
+	t.Run("InvalidOption", func(t *testing.T) {
+		is := is.New(t)
+		_, err := Infer(tab, WithCategoricalInference(0))
+		is.True(err != nil)
+	})
+}
+
+func TestInferMissingValues(t *testing.T) {
+	tab := table.FromSlices(
+		[]string{"Name", "Age", "Weight", "Empty"},
+		[][]string{
+			{"Foo", "10", "20.2", ""},
+			{"Bar", "N/A", "", "N/A"},
+			{"Bez", "", "30.5", ""},
+		})
+	t.Run("Default", func(t *testing.T) {
+		is := is.New(t)
+		s, err := Infer(tab)
+		is.NoErr(err)
+		is.Equal(s.Fields[2].Type, NumberType) // empty strings are not voted
+		is.Equal(s.Fields[2].Constraints.Required, false)
+		is.True(s.MissingValues == nil)
+	})
+	t.Run("Infer", func(t *testing.T) {
+		is := is.New(t)
+		s, err := Infer(tab, WithMissingValues("", "N/A"))
+		is.NoErr(err)
+		is.Equal(s.MissingValues, []string{"", "N/A"})
+		is.Equal(s.Fields[0].Type, StringType)
+		is.True(s.Fields[0].Constraints.Required)
+		is.Equal(s.Fields[1].Type, IntegerType)
+		is.True(!s.Fields[1].Constraints.Required)
+		is.Equal(s.Fields[2].Type, NumberType)
+		is.True(!s.Fields[2].Constraints.Required)
+		is.Equal(s.Fields[3].Type, StringType) // no values
+		is.True(!s.Fields[3].Constraints.Required)
+		// Missing values are in effect for the inferred schema.
+		v, err := s.Fields[1].Cast("N/A")
+		is.NoErr(err)
+		is.Equal(v, nil)
+	})
+	t.Run("InferImplicitCasting", func(t *testing.T) {
+		is := is.New(t)
+		s, err := InferImplicitCasting(tab, WithMissingValues("", "N/A"))
+		is.NoErr(err)
+		is.Equal(s.MissingValues, []string{"", "N/A"})
+		is.Equal(s.Fields[1].Type, IntegerType)
+		is.Equal(s.Fields[2].Type, NumberType)
+		is.Equal(s.Fields[3].Type, StringType)
+		is.True(s.Fields[0].Constraints.Required)
+		is.True(!s.Fields[1].Constraints.Required)
+	})
+	t.Run("Categorical", func(t *testing.T) {
+		is := is.New(t)
+		s, err := Infer(
+			table.FromSlices([]string{"Answer"}, [][]string{{"yes"}, {"N/A"}, {"yes"}, {"no"}}),
+			WithMissingValues("N/A"),
+			WithCategoricalInference(2))
+		is.NoErr(err)
+		is.Equal(s.Fields[0].Type, CategoricalType)
+		is.Equal(s.Fields[0].Categories, []Category{{Value: "no"}, {Value: "yes"}})
+	})
+}
+
 var (
 	benchmarkHeaders = []string{"Name", "Birthday", "Weight", "Address", "Siblings"}
 	benchmarkTable   = [][]string{
@@ -259,13 +353,13 @@ var (
 
 func benchmarkinfer(growthMultiplier int, b *testing.B) {
 	for n := 0; n < b.N; n++ {
-		infer(benchmarkHeaders, generateBenchmarkTable(growthMultiplier), orderedTypes)
+		infer(benchmarkHeaders, generateBenchmarkTable(growthMultiplier), &inferConfig{})
 	}
 }
 
 func benchmarkInferImplicitCasting(growthMultiplier int, b *testing.B) {
 	for n := 0; n < b.N; n++ {
-		inferImplicitCasting(benchmarkHeaders, generateBenchmarkTable(growthMultiplier))
+		inferImplicitCasting(benchmarkHeaders, generateBenchmarkTable(growthMultiplier), &inferConfig{})
 	}
 }
 
@@ -0,0 +1,277 @@
+package schema
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ConstraintInference configures the constraints inferred from the sampled data by
+// WithConstraintInference. Zero values disable the respective inference.
+type ConstraintInference struct {
+	// Bounds infers the minimum and maximum of integer, number, date, time, datetime,
+	// year, yearmonth and duration fields. Bounds are encoded like the field values,
+	// using the inferred format and decimal char.
+	Bounds bool
+	// Lengths infers the minLength and maxLength of string fields.
+	Lengths bool
+	// Required marks as required the fields in which no missing value was seen.
+	Required bool
+	// MinUniqueRows is the minimum number of non-missing values that must be seen to
+	// mark a field without duplicates as unique.
+	MinUniqueRows int
+	// MaxEnumValues is the maximum number of distinct values of a field for its values
+	// to be proposed as enum. As for categorical inference, at least one value must repeat.
+	MaxEnumValues int
+	// MaxPrimaryKeyFields is the maximum number of fields of the inferred primary key.
+	// The primary key is made of the fewest fields, in declaration order, that have no
+	// missing values and whose combined values are unique in at least MinUniqueRows rows.
+	MaxPrimaryKeyFields int
+}
+
+// DefaultConstraintInference infers all constraints using default thresholds.
+var DefaultConstraintInference = ConstraintInference{
+	Bounds:              true,
+	Lengths:             true,
+	Required:            true,
+	MinUniqueRows:       10,
+	MaxEnumValues:       10,
+	MaxPrimaryKeyFields: 2,
+}
+
+// WithConstraintInference makes inference also propose field constraints and a primary
+// key, based on the sampled data. Constraints are only as good as the sample, so
+// consider sampling all rows (see SampleLimit).
+func WithConstraintInference(c ConstraintInference) InferOpts {
+	return func(cfg *inferConfig) error {
+		if c.MinUniqueRows < 0 || c.MaxEnumValues < 0 || c.MaxPrimaryKeyFields < 0 {
+			return fmt.Errorf("constraint inference thresholds must not be negative, got:%+v", c)
+		}
+		if c.MaxPrimaryKeyFields > 0 && c.MinUniqueRows == 0 {
+			return fmt.Errorf("primary key inference requires the minimum number of unique rows")
+		}
+		cfg.constraints = &c
+		return nil
+	}
+}
+
+// inferredCaster returns a copy of the inferred field that is able to cast values:
+// properties not set by the inference get their default values.
+func inferredCaster(f *Field) Field {
+	c := withDefaults()
+	c.Name, c.Type, c.Format = f.Name, f.Type, f.Format
+	c.Categories = f.Categories
+	if f.DecimalChar != "" {
+		c.DecimalChar = f.DecimalChar
+	}
+	if f.GroupChar != "" {
+		c.GroupChar = f.GroupChar
+	}
+	if f.TrueValues != nil {
+		c.TrueValues = f.TrueValues
+	}
+	if f.FalseValues != nil {
+		c.FalseValues = f.FalseValues
+	}
+	// Missing values are skipped before casting.
+	c.MissingValues = map[string]struct{}{}
+	return c
+}
+
+// columnStats holds what is needed to infer the constraints of a column.
+type columnStats struct {
+	nulls    int
+	values   int
+	min, max interface{}
+	minLen   int
+	maxLen   int
+	// keys are the distinct values, normalized by casting. The map is nil once the
+	// values are known to have duplicates and too many distinct values for enum.
+	keys map[string]struct{}
+	// raw holds the first raw representation of the distinct values.
+	raw        []string
+	duplicates bool
+}
+
+// inferConstraints sets the constraints of the inferred schema fields, as configured
+// by WithConstraintInference.
+func inferConstraints(s *Schema, table [][]string, cfg *inferConfig) error {
+	c := cfg.constraints
+	missing := cfg.missingValueSet()
+	keys := make([][]string, len(s.Fields))
+	for index := range s.Fields {
+		f := &s.Fields[index]
+		caster := inferredCaster(f)
+		st := columnStats{keys: make(map[string]struct{})}
+		keys[index] = make([]string, len(table))
+		for rowID, row := range table {
+			cell := row[index]
+			if _, ok := missing[cell]; ok {
+				st.nulls++
+				continue
+			}
+			st.values++
+			key := cell
+			v, err := caster.Cast(cell)
+			if err == nil {
+				key = fmt.Sprintf("%v", v)
+				st.updateBounds(v)
+			}
+			keys[index][rowID] = key
+			if n := len([]rune(cell)); st.values == 1 || n < st.minLen {
+				st.minLen = n
+			}
+			if n := len([]rune(cell)); n > st.maxLen {
+				st.maxLen = n
+			}
+			if st.keys == nil {
+				continue
+			}
+			if _, ok := st.keys[key]; ok {
+				st.duplicates = true
+			} else {
+				st.keys[key] = struct{}{}
+				st.raw = append(st.raw, cell)
+			}
+			if st.duplicates && len(st.keys) > c.MaxEnumValues {
+				st.keys = nil
+			}
+		}
+		if err := st.apply(f, c); err != nil {
+			return fmt.Errorf("inferring constraints of field %s: %v", f.Name, err)
+		}
+		if st.nulls > 0 {
+			keys[index] = nil
+		}
+	}
+	if c.MaxPrimaryKeyFields > 0 && len(table) >= c.MinUniqueRows {
+		s.PrimaryKeys = inferPrimaryKey(keys, s.Fields, c.MaxPrimaryKeyFields)
+	}
+	return nil
+}
+
+func (st *columnStats) updateBounds(v interface{}) {
+	if st.min == nil {
+		if _, ok := compareValues(v, v); ok {
+			st.min, st.max = v, v
+		}
+		return
+	}
+	if cmp, ok := compareValues(v, st.min); ok && cmp < 0 {
+		st.min = v
+	}
+	if cmp, ok := compareValues(v, st.max); ok && cmp > 0 {
+		st.max = v
+	}
+}
+
+func (st *columnStats) apply(f *Field, c *ConstraintInference) error {
+	if c.Required && st.nulls == 0 && st.values > 0 {
+		f.Constraints.Required = true
+	}
+	if st.values == 0 {
+		return nil
+	}
+	// Only values of bounded types are comparable.
+	if c.Bounds && st.min != nil {
+		min, err := encodeInferredBound(f, st.min)
+		if err != nil {
+			return err
+		}
+		max, err := encodeInferredBound(f, st.max)
+		if err != nil {
+			return err
+		}
+		f.Constraints.Minimum, f.Constraints.Maximum = min, max
+	}
+	if f.Type == StringType && c.Lengths && st.minLen > 0 {
+		f.Constraints.MinLength, f.Constraints.MaxLength = st.minLen, st.maxLen
+	}
+	if c.MinUniqueRows > 0 && !st.duplicates && st.values >= c.MinUniqueRows {
+		f.Constraints.Unique = true
+	}
+	if c.MaxEnumValues > 0 && st.keys != nil && st.duplicates && f.Type != CategoricalType && f.Type != BooleanType {
+		raw := append([]string(nil), st.raw...)
+		sort.Strings(raw)
+		f.Constraints.Enum = make([]interface{}, len(raw))
+		for i, r := range raw {
+			f.Constraints.Enum[i] = r
+		}
+		return f.compile()
+	}
+	return nil
+}
+
+// encodeInferredBound encodes a bound like the values of the inferred field, that is,
+// using its format and decimal char.
+func encodeInferredBound(f *Field, v interface{}) (string, error) {
+	switch b := v.(type) {
+	case time.Time:
+		if f.Format != "" && f.Format != defaultFieldFormat && f.Format != AnyDateFormat {
+			return b.In(time.UTC).Format(goTimeLayout(f.Format)), nil
+		}
+	case float64:
+		s, err := encodeBound(f.Type, b)
+		if err != nil || f.DecimalChar == "" {
+			return s, err
+		}
+		return strings.Replace(s, defaultDecimalChar, f.DecimalChar, 1), nil
+	}
+	return encodeBound(f.Type, v)
+}
+
+// inferPrimaryKey returns the names of the fewest fields whose combined keys are unique.
+// Fields with nil keys (that is, fields with missing values) are not considered.
+func inferPrimaryKey(keys [][]string, fields []Field, maxFields int) []string {
+	var candidates []int
+	for i := range keys {
+		if keys[i] != nil {
+			candidates = append(candidates, i)
+		}
+	}
+	for size := 1; size <= maxFields && size <= len(candidates); size++ {
+		if pk := uniqueCombination(keys, candidates, size, nil); pk != nil {
+			names := make([]string, len(pk))
+			for i, index := range pk {
+				names[i] = fields[index].Name
+			}
+			return names
+		}
+	}
+	return nil
+}
+
+// uniqueCombination returns the first combination of size candidates, appended to
+// prefix, whose combined keys are unique. It returns nil if there is none.
+func uniqueCombination(keys [][]string, candidates []int, size int, prefix []int) []int {
+	if size == 0 {
+		if isUniqueCombination(keys, prefix) {
+			return prefix
+		}
+		return nil
+	}
+	for i := range candidates {
+		comb := append(append([]int(nil), prefix...), candidates[i])
+		if ret := uniqueCombination(keys, candidates[i+1:], size-1, comb); ret != nil {
+			return ret
+		}
+	}
+	return nil
+}
+
+func isUniqueCombination(keys [][]string, fields []int) bool {
+	seen := make(map[string]struct{})
+	for row := range keys[fields[0]] {
+		parts := make([]string, len(fields))
+		for i, f := range fields {
+			parts[i] = keys[f][row]
+		}
+		k := strings.Join(parts, "\x00")
+		if _, ok := seen[k]; ok {
+			return false
+		}
+		seen[k] = struct{}{}
+	}
+	return true
+}
@@ -0,0 +1,107 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+
+	"github.com/frictionlessdata/tableschema-go/table"
+)
+
+func TestInferConstraints(t *testing.T) {
+	tab := table.FromSlices(
+		[]string{"ID", "Name", "Age", "Birthday", "Color", "Score"},
+		[][]string{
+			{"1", "Foo", "10", "2015-10-12", "red", "1000000.5"},
+			{"2", "Bar", "", "2015-01-02", "blue", "2.5"},
+			{"3", "Bezz", "8", "2016-03-04", "red", "-3.25"},
+			{"4", "Boo", "21", "2014-12-31", "red", "2.5"},
+		})
+	t.Run("All", func(t *testing.T) {
+		is := is.New(t)
+		c := DefaultConstraintInference
+		c.MinUniqueRows = 3
+		s, err := Infer(tab, WithConstraintInference(c))
+		is.NoErr(err)
+
+		id := s.Fields[0].Constraints
+		is.True(id.Required)
+		is.True(id.Unique)
+		is.Equal(id.Minimum, "1")
+		is.Equal(id.Maximum, "4")
+
+		name := s.Fields[1].Constraints
+		is.Equal(name.MinLength, 3)
+		is.Equal(name.MaxLength, 4)
+		is.True(name.Unique)
+
+		age := s.Fields[2].Constraints
+		is.True(!age.Required)
+		is.Equal(age.Minimum, "8")
+		is.Equal(age.Maximum, "21")
+		is.True(age.Unique)
+
+		birthday := s.Fields[3].Constraints
+		is.Equal(birthday.Minimum, "2014-12-31")
+		is.Equal(birthday.Maximum, "2016-03-04")
+
+		color := s.Fields[4].Constraints
+		is.True(!color.Unique)
+		is.Equal(color.Enum, []interface{}{"blue", "red"})
+		is.Equal(color.MinLength, 3)
+
+		score := s.Fields[5].Constraints
+		is.Equal(score.Minimum, "-3.25")
+		is.Equal(score.Maximum, "1000000.5")
+		is.Equal(score.Enum, []interface{}{"-3.25", "1000000.5", "2.5"})
+
+		is.Equal(s.PrimaryKeys, []string{"ID"})
+		// The inferred schema accepts the sampled data.
+		is.NoErr(s.Validate())
+		rows, err := tab.ReadAll()
+		is.NoErr(err)
+		for _, row := range rows {
+			for i, cell := range row {
+				_, err := s.Fields[i].Cast(cell)
+				is.NoErr(err)
+			}
+		}
+	})
+	t.Run("Disabled", func(t *testing.T) {
+		is := is.New(t)
+		s, err := Infer(tab, WithConstraintInference(ConstraintInference{Lengths: true}))
+		is.NoErr(err)
+		is.Equal(s.Fields[0].Constraints, Constraints{})
+		is.Equal(s.Fields[1].Constraints, Constraints{MinLength: 3, MaxLength: 4})
+		is.True(s.PrimaryKeys == nil)
+	})
+	t.Run("CompositePrimaryKey", func(t *testing.T) {
+		is := is.New(t)
+		s, err := InferImplicitCasting(
+			table.FromSlices(
+				[]string{"Year", "Month", "Comment", "Value"},
+				[][]string{
+					{"2020", "1", "", "10"},
+					{"2020", "2", "x", "10"},
+					{"2021", "1", "y", "20"},
+					{"2021", "2", "z", "20"},
+				}),
+			WithConstraintInference(ConstraintInference{MinUniqueRows: 4, MaxPrimaryKeyFields: 2}))
+		is.NoErr(err)
+		is.Equal(s.PrimaryKeys, []string{"Year", "Month"})
+	})
+	t.Run("NotEnoughRows", func(t *testing.T) {
+		is := is.New(t)
+		s, err := Infer(tab, WithConstraintInference(ConstraintInference{MinUniqueRows: 5, MaxPrimaryKeyFields: 1}))
+		is.NoErr(err)
+		is.True(!s.Fields[0].Constraints.Unique)
+		is.True(s.PrimaryKeys == nil)
+	})
+	t.Run("InvalidOption", func(t *testing.T) {
+		is := is.New(t)
+		_, err := Infer(tab, WithConstraintInference(ConstraintInference{MaxEnumValues: -1}))
+		is.True(err != nil)
+		_, err = Infer(tab, WithConstraintInference(ConstraintInference{MaxPrimaryKeyFields: 1}))
+		is.True(err != nil)
+	})
+}
@@ -0,0 +1,213 @@
+package schema
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// formatCandidate is a field type along with the properties used to cast its values,
+// which is tried by format inference.
+type formatCandidate struct {
+	fieldType   FieldType
+	format      string
+	decimalChar string
+	groupChar   string
+	trueValues  []string
+	falseValues []string
+	// match adds checks to casting. It might be nil.
+	match func(value string) bool
+	// check returns true if the value was cast to the expected value. It might be nil.
+	check func(value string, castd interface{}) bool
+}
+
+func (c *formatCandidate) caster() Field {
+	f := withDefaults()
+	f.Type = c.fieldType
+	f.Format = c.format
+	if c.decimalChar != "" {
+		f.DecimalChar, f.GroupChar = c.decimalChar, c.groupChar
+	}
+	if c.trueValues != nil {
+		f.TrueValues, f.FalseValues = c.trueValues, c.falseValues
+	}
+	f.MissingValues = map[string]struct{}{}
+	return f
+}
+
+// apply sets the type and properties of the candidate in the inferred field.
+func (c *formatCandidate) apply(f *Field) {
+	f.Type = c.fieldType
+	f.Format = c.format
+	if f.Format == "" {
+		f.Format = defaultFieldFormat
+	}
+	f.DecimalChar, f.GroupChar = c.decimalChar, c.groupChar
+	f.TrueValues, f.FalseValues = c.trueValues, c.falseValues
+}
+
+// matches returns true if all values can be cast using the candidate.
+func (c *formatCandidate) matches(values []string) bool {
+	caster := c.caster()
+	for _, v := range values {
+		if c.match != nil && !c.match(v) {
+			return false
+		}
+		castd, err := caster.Cast(v)
+		if err != nil || (c.check != nil && !c.check(v, castd)) {
+			return false
+		}
+	}
+	return true
+}
+
+// numberCandidate returns a number candidate which only matches numbers whose digits
+// are properly grouped by groupChar.
+func numberCandidate(decimalChar, groupChar string) formatCandidate {
+	re := regexp.MustCompile(fmt.Sprintf(`^[-+]?(\d+|\d{1,3}(%s\d{3})+)?(%s\d+)?([eE][-+]?\d+)?$`, regexp.QuoteMeta(groupChar), regexp.QuoteMeta(decimalChar)))
+	// Both chars are replaced at once, so the expected value does not depend on the
+	// order castNumber handles them.
+	r := strings.NewReplacer(groupChar, "", decimalChar, ".")
+	check := func(value string, castd interface{}) bool {
+		want, err := strconv.ParseFloat(r.Replace(value), 64)
+		return err == nil && castd == want
+	}
+	c := formatCandidate{fieldType: NumberType, match: re.MatchString, check: check}
+	if decimalChar != defaultDecimalChar || groupChar != defaultGroupChar {
+		c.decimalChar, c.groupChar = decimalChar, groupChar
+	}
+	return c
+}
+
+func booleanCandidate(trueValue, falseValue string) formatCandidate {
+	return formatCandidate{fieldType: BooleanType, trueValues: []string{trueValue}, falseValues: []string{falseValue}}
+}
+
+// validGeoPoint returns true if the coordinates of the geopoint are in range.
+func validGeoPoint(format string) func(string) bool {
+	return func(value string) bool {
+		p, err := castGeoPoint(format, value)
+		return err == nil && p.Lon >= -180 && p.Lon <= 180 && p.Lat >= -90 && p.Lat <= 90
+	}
+}
+
+var (
+	// numberCandidates are tried, in order, for number columns. The first one matches
+	// numbers written using the default decimal and group chars.
+	numberCandidates = []formatCandidate{
+		numberCandidate(".", ","),
+		numberCandidate(",", "."),
+		numberCandidate(",", " "),
+		numberCandidate(".", " "),
+		numberCandidate(".", "'"),
+		numberCandidate(",", "'"),
+	}
+
+	// geoPointCandidates are tried, in order, for array and object columns.
+	geoPointCandidates = []formatCandidate{
+		{fieldType: GeoPointType, format: GeoPointArrayFormat, match: validGeoPoint(GeoPointArrayFormat)},
+		{fieldType: GeoPointType, format: GeoPointObjectFormat, match: validGeoPoint(GeoPointObjectFormat)},
+	}
+
+	// stringCandidates are tried, in order, for string columns.
+	stringCandidates = append(append([]formatCandidate{
+		booleanCandidate("yes", "no"),
+		booleanCandidate("Yes", "No"),
+		booleanCandidate("YES", "NO"),
+		booleanCandidate("y", "n"),
+		booleanCandidate("Y", "N"),
+		booleanCandidate("t", "f"),
+		booleanCandidate("T", "F"),
+	}, numberCandidates...),
+		formatCandidate{fieldType: DateType, format: "%d/%m/%Y"},
+		formatCandidate{fieldType: DateType, format: "%m/%d/%Y"},
+		formatCandidate{fieldType: DateType, format: "%Y/%m/%d"},
+		formatCandidate{fieldType: DateType, format: "%d-%m-%Y"},
+		formatCandidate{fieldType: DateType, format: "%d.%m.%Y"},
+		formatCandidate{fieldType: DateType, format: "%Y%m%d"},
+		formatCandidate{fieldType: DateType, format: "%d %b %Y"},
+		formatCandidate{fieldType: DateType, format: "%b %d, %Y"},
+		formatCandidate{fieldType: TimeType, format: "%H:%M"},
+		formatCandidate{fieldType: TimeType, format: "%H:%M:%S"},
+		formatCandidate{fieldType: TimeType, format: "%I:%M %p"},
+		formatCandidate{fieldType: StringType, format: stringUUID},
+		formatCandidate{fieldType: StringType, format: stringEmail},
+		formatCandidate{fieldType: StringType, format: stringURI},
+	)
+)
+
+// WithFormatInference makes inference try non-default formats and properties for the
+// fields, which are set when all non-missing sampled values of a column can be cast
+// using them. For instance, 17/10/2026 is inferred as a date of format "%d/%m/%Y",
+// 1.234,56 as a number using "," as decimalChar and "." as groupChar and yes/no as
+// booleans. Candidates are tried in order and the first match wins, so ambiguous
+// dates like 01/02/2026 are read day first.
+//
+// String columns are tried against boolean values, number chars, date and time
+// patterns and the uuid, email and uri formats. Number columns are checked for
+// decimal and group chars, and array and object columns for geopoint formats.
+// Geopoint columns are checked for numbers using non-default chars only if a value
+// is out of the coordinate ranges, like 200,75, or groups digits using ".", like
+// 1.234,56. Otherwise, values like 90,45 are kept as geopoints.
+func WithFormatInference() InferOpts {
+	return func(c *inferConfig) error {
+		c.formats = true
+		return nil
+	}
+}
+
+// inferFormats sets the formats of the inferred schema fields.
+func inferFormats(s *Schema, table [][]string, cfg *inferConfig) {
+	missing := cfg.missingValueSet()
+	for index := range s.Fields {
+		f := &s.Fields[index]
+		var candidates []formatCandidate
+		switch f.Type {
+		case StringType:
+			candidates = stringCandidates
+		case NumberType:
+			candidates = numberCandidates
+		case GeoPointType:
+			// Numbers using "," as decimal char look like geopoints. They are only
+			// tried if some value does not look like a geopoint (see numberLike).
+			candidates = numberCandidates[1:]
+		case ArrayType, ObjectType:
+			candidates = geoPointCandidates
+		default:
+			continue
+		}
+		var values []string
+		for _, row := range table {
+			if _, ok := missing[row[index]]; !ok {
+				values = append(values, row[index])
+			}
+		}
+		if len(values) == 0 || (f.Type == GeoPointType && !numberLike(values)) {
+			continue
+		}
+		for i := range candidates {
+			if candidates[i].matches(values) {
+				candidates[i].apply(f)
+				break
+			}
+		}
+	}
+}
+
+// groupedNumberRegexp matches numbers grouping digits using ".", which default
+// geopoints never do.
+var groupedNumberRegexp = regexp.MustCompile(`^[-+]?\d{1,3}(\.\d{3})+(,\d+)?$`)
+
+// numberLike returns true if some of the values inferred as default geopoints are
+// rather numbers: either their coordinates are out of range or their digits are
+// grouped.
+func numberLike(values []string) bool {
+	inRange := validGeoPoint(defaultFieldFormat)
+	for _, v := range values {
+		if !inRange(v) || groupedNumberRegexp.MatchString(v) {
+			return true
+		}
+	}
+	return false
+}
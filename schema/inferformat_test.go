@@ -0,0 +1,125 @@
+package schema
+
+import (
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+
+	"github.com/frictionlessdata/tableschema-go/table"
+)
+
+func TestInferFormats(t *testing.T) {
+	data := []struct {
+		desc   string
+		values []string
+		want   Field
+	}{
+		{"DayFirstDate", []string{"17/10/2026", "01/02/2026"}, Field{Type: DateType, Format: "%d/%m/%Y"}},
+		{"MonthFirstDate", []string{"10/17/2026", "01/02/2026"}, Field{Type: DateType, Format: "%m/%d/%Y"}},
+		{"DottedDate", []string{"17.10.2026"}, Field{Type: DateType, Format: "%d.%m.%Y"}},
+		{"DefaultDate", []string{"2026-10-17"}, Field{Type: DateType, Format: defaultFieldFormat}},
+		{"ShortTime", []string{"10:15", "23:59"}, Field{Type: TimeType, Format: "%H:%M"}},
+		{"DefaultNumber", []string{"1,234.56", "10.5"}, Field{Type: NumberType, Format: defaultFieldFormat}},
+		{"CommaDecimal", []string{"1.234,56", "10,5"}, Field{Type: NumberType, Format: defaultFieldFormat, DecimalChar: ",", GroupChar: "."}},
+		{"CommaDecimalOutOfRange", []string{"200,75", "3,25"}, Field{Type: NumberType, Format: defaultFieldFormat, DecimalChar: ",", GroupChar: "."}},
+		{"SpaceGroup", []string{"1 234,5", "10"}, Field{Type: NumberType, Format: defaultFieldFormat, DecimalChar: ",", GroupChar: " "}},
+		{"EuropeanThousands", []string{"1.234.567", "2.000.000"}, Field{Type: NumberType, Format: defaultFieldFormat, DecimalChar: ",", GroupChar: "."}},
+		{"YesNo", []string{"yes", "no", "yes"}, Field{Type: BooleanType, Format: defaultFieldFormat, TrueValues: []string{"yes"}, FalseValues: []string{"no"}}},
+		{"Email", []string{"foo@bar.com", "bar@foo.org"}, Field{Type: StringType, Format: stringEmail}},
+		{"URI", []string{"http://foo.com", "https://bar.org/x"}, Field{Type: StringType, Format: stringURI}},
+		{"UUID", []string{"8c1a7e2c-3f4b-4d5e-9a6b-7c8d9e0f1a2b"}, Field{Type: StringType, Format: stringUUID}},
+		{"GeoPointArray", []string{"[90, 45]", "[-10.5, 20]"}, Field{Type: GeoPointType, Format: GeoPointArrayFormat}},
+		{"GeoPointObject", []string{`{"lon": 90, "lat": 45}`}, Field{Type: GeoPointType, Format: GeoPointObjectFormat}},
+		{"DefaultGeoPoint", []string{"-22.9, -43.2", "10.5,20.1"}, Field{Type: GeoPointType, Format: defaultFieldFormat}},
+		{"IntegerGeoPoint", []string{"10,10", "20,30"}, Field{Type: GeoPointType, Format: defaultFieldFormat}},
+		{"ArrayOutOfRange", []string{"[190, 45]"}, Field{Type: ArrayType, Format: defaultFieldFormat}},
+		{"Mixed", []string{"17/10/2026", "foo@bar.com"}, Field{Type: StringType, Format: defaultFieldFormat}},
+	}
+	for _, d := range data {
+		t.Run(d.desc, func(t *testing.T) {
+			is := is.New(t)
+			rows := [][]string{{""}}
+			for _, v := range d.values {
+				rows = append(rows, []string{v})
+			}
+			s, err := InferImplicitCasting(table.FromSlices([]string{"f"}, rows), WithFormatInference())
+			is.NoErr(err)
+			d.want.Name = "f"
+			is.Equal(s.Fields[0], d.want)
+			// Inferred fields cast the sampled values.
+			caster := inferredCaster(&s.Fields[0])
+			for _, v := range d.values {
+				_, err := caster.Cast(v)
+				is.NoErr(err)
+			}
+		})
+	}
+	t.Run("NumberValues", func(t *testing.T) {
+		data := []struct {
+			desc   string
+			values []string
+			want   []float64
+		}{
+			{"DefaultChars", []string{"1,234.56", "10.5"}, []float64{1234.56, 10.5}},
+			{"DotGroup", []string{"1.234,56", "2.000,5", "10"}, []float64{1234.56, 2000.5, 10}},
+			{"EuropeanThousands", []string{"1.234.567", "2.000.000"}, []float64{1234567, 2000000}},
+			{"SpaceGroup", []string{"1 234,5", "10"}, []float64{1234.5, 10}},
+			{"QuoteGroup", []string{"1'234.5", "10"}, []float64{1234.5, 10}},
+		}
+		for _, d := range data {
+			t.Run(d.desc, func(t *testing.T) {
+				is := is.New(t)
+				rows := make([][]string, len(d.values))
+				for i, v := range d.values {
+					rows[i] = []string{v}
+				}
+				s, err := InferImplicitCasting(table.FromSlices([]string{"f"}, rows), WithFormatInference())
+				is.NoErr(err)
+				caster := inferredCaster(&s.Fields[0])
+				for i, v := range d.values {
+					got, err := caster.Cast(v)
+					is.NoErr(err)
+					is.Equal(got, d.want[i])
+				}
+			})
+		}
+	})
+	t.Run("Disabled", func(t *testing.T) {
+		is := is.New(t)
+		s, err := Infer(table.FromSlices([]string{"f"}, [][]string{{"17/10/2026"}}))
+		is.NoErr(err)
+		is.Equal(s.Fields[0].Type, StringType)
+	})
+	t.Run("WithConstraints", func(t *testing.T) {
+		is := is.New(t)
+		rows := [][]string{
+			{"17/10/2026", "1.234,5", "10:15"},
+			{"01/02/2026", "10,25", "23:59"},
+			{"05/11/2025", "-3", "09:00"},
+		}
+		s, err := Infer(
+			table.FromSlices([]string{"date", "number", "time"}, rows),
+			WithFormatInference(),
+			WithConstraintInference(ConstraintInference{Bounds: true}))
+		is.NoErr(err)
+		// Bounds are encoded like the values.
+		is.Equal(s.Fields[0].Constraints.Minimum, "05/11/2025")
+		is.Equal(s.Fields[0].Constraints.Maximum, "17/10/2026")
+		is.Equal(s.Fields[1].Constraints.Minimum, "-3")
+		is.Equal(s.Fields[1].Constraints.Maximum, "1234,5")
+		is.Equal(s.Fields[2].Constraints.Minimum, "09:00")
+		is.Equal(s.Fields[2].Constraints.Maximum, "23:59")
+		is.NoErr(s.Validate())
+		for _, row := range rows {
+			var got struct {
+				Date   time.Time
+				Number float64
+				Time   time.Time
+			}
+			is.NoErr(s.CastRow(row, &got))
+		}
+		_, err = s.Fields[1].Cast("1.234,6")
+		is.True(err != nil)
+	})
+}
@@ -0,0 +1,81 @@
+package schema
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+
+	"github.com/frictionlessdata/tableschema-go/table"
+)
+
+// WithWorkers sets the maximum number of tables inferred concurrently by InferMany.
+// It defaults to the number of CPUs usable by the process (see runtime.GOMAXPROCS).
+func WithWorkers(n int) InferOpts {
+	return func(c *inferConfig) error {
+		if n <= 0 {
+			return fmt.Errorf("number of workers must be positive, got:%d", n)
+		}
+		c.workers = n
+		return nil
+	}
+}
+
+// InferMany infers a single schema from many tables, for instance, the files a dataset
+// is partitioned into. The schema of each table is inferred as done by Infer, using the
+// same options, by a bounded pool of workers (see WithWorkers). The schemas are then
+// reconciled by Merge.
+//
+// When WithInferReport is passed, the report holds the report of each table, the type
+// widenings along with the table which caused them and the properties that could not be
+// reconciled. InferMany returns the error of the first failing table, if any.
+func InferMany(tables []table.Table, opts ...InferOpts) (*Schema, error) {
+	cfg, err := newInferConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+	workers := cfg.workers
+	if workers == 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	var reports []InferReport
+	if cfg.report != nil {
+		reports = make([]InferReport, len(tables))
+	}
+	schemas := make([]*Schema, len(tables))
+	errs := make([]error, len(tables))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers && w < len(tables); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				// Each table is inferred using its own configuration, which holds its report.
+				tcfg, err := newInferConfig(opts)
+				if err != nil {
+					errs[i] = err
+					continue
+				}
+				if reports != nil {
+					tcfg.report = &reports[i]
+				}
+				schemas[i], errs[i] = inferWithConfig(tables[i], false, tcfg)
+			}
+		}()
+	}
+	for i := range tables {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	for i, err := range errs {
+		if err != nil {
+			return nil, fmt.Errorf("inferring table %d: %w", i, err)
+		}
+	}
+	s, r := Merge(schemas...)
+	if cfg.report != nil {
+		*cfg.report = InferReport{Tables: reports, Widenings: r.Widenings, Conflicts: r.Conflicts}
+	}
+	return s, nil
+}
@@ -0,0 +1,60 @@
+package schema
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/matryer/is"
+
+	"github.com/frictionlessdata/tableschema-go/table"
+)
+
+func TestInferMany(t *testing.T) {
+	tables := []table.Table{
+		table.FromSlices([]string{"ID", "Amount"}, [][]string{{"11", "10"}, {"12", "20"}}),
+		table.FromSlices([]string{"ID", "Amount", "Comment"}, [][]string{{"13", "10.5", "foo"}}),
+		table.FromSlices([]string{"ID", "Amount"}, [][]string{{"14", "N/A"}, {"15", "30"}}),
+	}
+	t.Run("Merge", func(t *testing.T) {
+		is := is.New(t)
+		var r InferReport
+		s, err := InferMany(tables, WithMissingValues("", "N/A"), WithWorkers(2), WithInferReport(&r))
+		is.NoErr(err)
+		is.Equal(len(s.Fields), 3)
+		is.Equal(s.Fields[0].Type, IntegerType)
+		is.True(s.Fields[0].Constraints.Required)
+		is.Equal(s.Fields[1].Type, NumberType)
+		is.True(!s.Fields[1].Constraints.Required)
+		is.Equal(s.Fields[2].Type, StringType)
+		is.True(!s.Fields[2].Constraints.Required)
+		is.Equal(s.MissingValues, []string{"", "N/A"})
+
+		is.Equal(len(r.Tables), 3)
+		is.Equal(r.Tables[2].Columns[1].Nulls, 1)
+		want := []Widening{{Field: "Amount", Schema: 1, From: IntegerType, To: NumberType}}
+		if !reflect.DeepEqual(r.Widenings, want) {
+			t.Fatalf("got:%v want:%v", r.Widenings, want)
+		}
+	})
+	t.Run("SameResultAsSequential", func(t *testing.T) {
+		is := is.New(t)
+		s1, err := InferMany(tables, WithWorkers(1))
+		is.NoErr(err)
+		s8, err := InferMany(tables, WithWorkers(8))
+		is.NoErr(err)
+		is.True(reflect.DeepEqual(s1, s8))
+	})
+	t.Run("Error", func(t *testing.T) {
+		is := is.New(t)
+		_, err := InferMany(append(tables, table.FromSlices([]string{"ID"}, [][]string{{"1", "2"}})))
+		is.True(err != nil)
+		_, err = InferMany(tables, WithWorkers(0))
+		is.True(err != nil)
+	})
+	t.Run("NoTables", func(t *testing.T) {
+		is := is.New(t)
+		s, err := InferMany(nil)
+		is.NoErr(err)
+		is.Equal(len(s.Fields), 0)
+	})
+}
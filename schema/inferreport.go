@@ -0,0 +1,182 @@
+package schema
+
+import "fmt"
+
+// maxOffendingValues is the maximum number of offending values kept per column by
+// InferReport.
+const maxOffendingValues = 5
+
+// InferReport describes how the types of an inferred schema were chosen, so the
+// result can be audited. It is filled by passing WithInferReport.
+type InferReport struct {
+	Columns []ColumnReport
+	// RaggedRows is the policy applied to the ragged rows (see WithRaggedRows).
+	RaggedRows RaggedRows
+	// Ragged describes the first ragged rows read from the table.
+	Ragged []RaggedRow
+	// RaggedCount is the number of ragged rows read from the table.
+	RaggedCount int
+
+	// Tables holds the report of each table inferred by InferMany, in order.
+	Tables []InferReport
+	// Widenings lists the field types widened by InferMany to merge the schemas of the
+	// tables. The Schema of each widening is the index of the table which caused it.
+	Widenings []Widening
+	// Conflicts lists the properties InferMany could not reconcile (see Merge).
+	Conflicts []MergeConflict
+}
+
+// ColumnReport describes how the type of a column was inferred.
+type ColumnReport struct {
+	// Name is the column header.
+	Name string
+	// Type is the type inferred from the cells, before format and categorical inference.
+	Type FieldType
+	// Nulls is the number of missing cells.
+	Nulls int
+	// Types counts the non-missing cells by the narrowest type they were inferred as.
+	Types map[FieldType]int
+	// Confidence is the fraction of non-missing cells that can be cast to Type, which
+	// is 1 if the column has no non-missing cells.
+	Confidence float64
+	// Offending holds examples of values that can not be cast to Type.
+	Offending []string
+}
+
+// WithInferReport makes inference fill r with the type statistics of each column.
+// When passed to InferImplicitCasting, cells are checked against all types, which
+// makes inference slower.
+func WithInferReport(r *InferReport) InferOpts {
+	return func(c *inferConfig) error {
+		if r == nil {
+			return fmt.Errorf("infer report must not be nil")
+		}
+		c.report = r
+		return nil
+	}
+}
+
+// WithConfidence sets the minimum fraction of the non-missing cells of a column that
+// must be cast to the inferred type. When the most popular type of a column does not
+// reach the threshold, Infer widens it to the narrowest type it can be implicitly
+// cast to (see InferImplicitCasting) that does, which is string in the worst case. For instance, with a 0.95 threshold,
+// a column in which 90% of the cells are integers and the others are numbers is
+// inferred as number.
+func WithConfidence(threshold float64) InferOpts {
+	return func(c *inferConfig) error {
+		if threshold <= 0 || threshold > 1 {
+			return fmt.Errorf("confidence threshold must be in (0, 1], got:%v", threshold)
+		}
+		c.confidence = threshold
+		return nil
+	}
+}
+
+// columnTypes holds the type statistics of a column.
+type columnTypes struct {
+	nulls  int
+	counts map[FieldType]int
+	// examples holds some values of each type.
+	examples map[FieldType][]string
+}
+
+func newColumnTypes(n int) []columnTypes {
+	columns := make([]columnTypes, n)
+	for i := range columns {
+		columns[i].counts = make(map[FieldType]int)
+		columns[i].examples = make(map[FieldType][]string)
+	}
+	return columns
+}
+
+func (c *columnTypes) add(value string, t FieldType) {
+	c.counts[t]++
+	if len(c.examples[t]) < maxOffendingValues {
+		c.examples[t] = append(c.examples[t], value)
+	}
+}
+
+func (c *columnTypes) values() int {
+	n := 0
+	for _, count := range c.counts {
+		n += count
+	}
+	return n
+}
+
+// coverage returns the number of non-missing cells that can be cast to the type.
+func (c *columnTypes) coverage(t FieldType) int {
+	n := 0
+	for u, count := range c.counts {
+		if covers(t, u) {
+			n += count
+		}
+	}
+	return n
+}
+
+// covers returns true if values of type u can be cast to type t.
+func covers(t, u FieldType) bool {
+	return t == StringType || widensTo(u, t)
+}
+
+// vote returns the most popular type of the column. Ties are broken by the order of
+// the types in precedenceOrder. If the confidence is positive and the most popular
+// type does not reach it, the type is widened.
+func (c *columnTypes) vote(precedenceOrder []FieldType, confidence float64) FieldType {
+	rank := func(t FieldType) int {
+		for i, o := range precedenceOrder {
+			if o == t {
+				return i
+			}
+		}
+		return len(precedenceOrder)
+	}
+	var best FieldType
+	for t, count := range c.counts {
+		if best == "" || count > c.counts[best] || (count == c.counts[best] && rank(t) < rank(best)) {
+			best = t
+		}
+	}
+	if best == "" {
+		return defaultFieldType
+	}
+	if confidence > 0 {
+		total := float64(c.values())
+		for _, t := range append(inferableTypes(), StringType) {
+			if covers(t, best) && float64(c.coverage(t))/total >= confidence {
+				return t
+			}
+		}
+	}
+	return best
+}
+
+func (c *columnTypes) report(name string, t FieldType) ColumnReport {
+	r := ColumnReport{Name: name, Type: t, Nulls: c.nulls, Types: c.counts, Confidence: 1}
+	if total := c.values(); total > 0 {
+		r.Confidence = float64(c.coverage(t)) / float64(total)
+	}
+	for _, u := range append(inferableTypes(), StringType) {
+		if covers(t, u) {
+			continue
+		}
+		for _, v := range c.examples[u] {
+			if len(r.Offending) < maxOffendingValues {
+				r.Offending = append(r.Offending, v)
+			}
+		}
+	}
+	return r
+}
+
+// fillReport fills the report requested by WithInferReport, if any.
+func (c *inferConfig) fillReport(s *Schema, columns []columnTypes) {
+	if c.report == nil {
+		return
+	}
+	c.report.Columns = make([]ColumnReport, len(s.Fields))
+	for i := range s.Fields {
+		c.report.Columns[i] = columns[i].report(s.Fields[i].Name, s.Fields[i].Type)
+	}
+}
@@ -0,0 +1,152 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+
+	"github.com/frictionlessdata/tableschema-go/table"
+)
+
+func TestInferConfidence(t *testing.T) {
+	column := func(values ...string) table.Table {
+		rows := make([][]string, len(values))
+		for i, v := range values {
+			rows[i] = []string{v}
+		}
+		return table.FromSlices([]string{"f"}, rows)
+	}
+	data := []struct {
+		desc       string
+		tab        table.Table
+		confidence float64
+		want       FieldType
+	}{
+		{"TieBrokenByPrecedence", column("10", "10.5"), 0, IntegerType},
+		{"TieBrokenByPrecedenceReversed", column("10.5", "10"), 0, IntegerType},
+		{"Majority", column("10", "10", "10.5"), 0, IntegerType},
+		{"WidenedToNumber", column("10", "10", "10.5"), 0.9, NumberType},
+		{"WidenedToString", column("10", "10", "foo"), 0.9, StringType},
+		{"ThresholdReached", column("10", "10", "10", "foo"), 0.75, IntegerType},
+		{"MissingValuesNotCounted", column("10", "", "", "10.5", "10"), 0.6, IntegerType},
+		{"YearMonthWidenedToDate", column("2017-08", "2017-08-01", "2017-09-01"), 1, DateType},
+	}
+	for _, d := range data {
+		t.Run(d.desc, func(t *testing.T) {
+			is := is.New(t)
+			var opts []InferOpts
+			if d.confidence > 0 {
+				opts = append(opts, WithConfidence(d.confidence))
+			}
+			for i := 0; i < 10; i++ { // Map iteration order must not matter.
+				s, err := Infer(d.tab, opts...)
+				is.NoErr(err)
+				is.Equal(s.Fields[0].Type, d.want)
+			}
+		})
+	}
+	t.Run("InvalidOption", func(t *testing.T) {
+		is := is.New(t)
+		_, err := Infer(column("1"), WithConfidence(0))
+		is.True(err != nil)
+		_, err = Infer(column("1"), WithConfidence(1.1))
+		is.True(err != nil)
+	})
+}
+
+func TestJoinTypes(t *testing.T) {
+	data := []struct {
+		a, b FieldType
+		want FieldType
+	}{
+		{IntegerType, IntegerType, IntegerType},
+		{IntegerType, NumberType, NumberType},
+		{NumberType, IntegerType, NumberType},
+		{BooleanType, YearType, IntegerType},
+		{DateType, YearMonthType, DateType},
+		{YearMonthType, DateTimeType, DateTimeType},
+		{GeoPointType, ArrayType, ArrayType},
+		{IntegerType, DateType, StringType},
+		{StringType, IntegerType, StringType},
+	}
+	for _, d := range data {
+		t.Run(string(d.a)+"_"+string(d.b), func(t *testing.T) {
+			is := is.New(t)
+			is.Equal(joinTypes(d.a, d.b), d.want)
+		})
+	}
+}
+
+func TestInferImplicitCasting_Join(t *testing.T) {
+	is := is.New(t)
+	s, err := InferImplicitCasting(table.FromSlices(
+		[]string{"Date", "DateTime"},
+		[][]string{
+			{"2017-08-01", "2017-08"},
+			{"2017-09", "2017-08-01"},
+			{"2017-10-02", "2008-09-15T15:53:00+05:00"},
+		}))
+	is.NoErr(err)
+	is.Equal(s.Fields[0].Type, DateType)
+	is.Equal(s.Fields[1].Type, DateTimeType)
+}
+
+func TestInferReport(t *testing.T) {
+	tab := table.FromSlices(
+		[]string{"Age", "Name", "Empty"},
+		[][]string{
+			{"10", "Foo", ""},
+			{"N/A", "Bar", ""},
+			{"", "Bez", ""},
+			{"12", "Boo", ""},
+			{"11", "Baz", ""},
+		})
+	t.Run("Infer", func(t *testing.T) {
+		is := is.New(t)
+		var r InferReport
+		s, err := Infer(tab, WithInferReport(&r))
+		is.NoErr(err)
+		is.Equal(s.Fields[0].Type, IntegerType)
+		is.Equal(len(r.Columns), 3)
+		age := r.Columns[0]
+		is.Equal(age.Name, "Age")
+		is.Equal(age.Type, IntegerType)
+		is.Equal(age.Nulls, 1)
+		is.Equal(age.Types, map[FieldType]int{IntegerType: 3, StringType: 1})
+		is.Equal(age.Confidence, 0.75)
+		is.Equal(age.Offending, []string{"N/A"})
+
+		name := r.Columns[1]
+		is.Equal(name.Type, StringType)
+		is.Equal(name.Confidence, 1.0)
+		is.True(name.Offending == nil)
+
+		empty := r.Columns[2]
+		is.Equal(empty.Type, StringType)
+		is.Equal(empty.Nulls, 5)
+		is.Equal(empty.Confidence, 1.0)
+	})
+	t.Run("InferImplicitCasting", func(t *testing.T) {
+		is := is.New(t)
+		var r InferReport
+		s, err := InferImplicitCasting(tab, WithInferReport(&r))
+		is.NoErr(err)
+		is.Equal(s.Fields[0].Type, StringType)
+		is.Equal(r.Columns[0].Type, StringType)
+		is.Equal(r.Columns[0].Types, map[FieldType]int{IntegerType: 3, StringType: 1})
+		is.Equal(r.Columns[0].Confidence, 1.0)
+	})
+	t.Run("MissingValues", func(t *testing.T) {
+		is := is.New(t)
+		var r InferReport
+		_, err := Infer(tab, WithInferReport(&r), WithMissingValues("", "N/A"))
+		is.NoErr(err)
+		is.Equal(r.Columns[0].Nulls, 2)
+		is.Equal(r.Columns[0].Confidence, 1.0)
+	})
+	t.Run("InvalidOption", func(t *testing.T) {
+		is := is.New(t)
+		_, err := Infer(tab, WithInferReport(nil))
+		is.True(err != nil)
+	})
+}
@@ -0,0 +1,107 @@
+package schema
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Defaults for list fields.
+const (
+	defaultListDelimiter = ","
+	defaultListItemType  = StringType
+)
+
+// listItem returns the field used to cast each item of a list field. It inherits
+// the type-specific properties of the list field and the constraints that are
+// applicable to list items: minimum, maximum, pattern and enum. Length
+// constraints apply to the list itself.
+func (f *Field) listItem() Field {
+	itemType := f.ItemType
+	if itemType == "" {
+		itemType = defaultListItemType
+	}
+	return Field{
+		Name:        f.Name,
+		Type:        itemType,
+		Format:      f.Format,
+		TrueValues:  f.TrueValues,
+		FalseValues: f.FalseValues,
+		DecimalChar: f.DecimalChar,
+		GroupChar:   f.GroupChar,
+		BareNumber:  f.BareNumber,
+		// Empty items are not null, they are cast according to the item type.
+		MissingValues: map[string]struct{}{},
+		Constraints: Constraints{
+			Maximum:          f.Constraints.Maximum,
+			Minimum:          f.Constraints.Minimum,
+			ExclusiveMaximum: f.Constraints.ExclusiveMaximum,
+			ExclusiveMinimum: f.Constraints.ExclusiveMinimum,
+			Pattern:          f.Constraints.Pattern,
+			compiledPattern:  f.Constraints.compiledPattern,
+			Enum:             f.Constraints.Enum,
+			rawEnum:          f.Constraints.rawEnum,
+		},
+	}
+}
+
+func (f *Field) listDelimiter() string {
+	if f.Delimiter == "" {
+		return defaultListDelimiter
+	}
+	return f.Delimiter
+}
+
+// castList splits the value using the field delimiter and casts each item. Items
+// are returned as a typed slice: []string, []int64, []float64, []bool or []time.Time.
+func castList(f *Field, value string) (interface{}, error) {
+	var items []string
+	if value != "" {
+		items = strings.Split(value, f.listDelimiter())
+	}
+	if err := checkLengthConstraints(value, len(items), f.Constraints); err != nil {
+		return nil, err
+	}
+	item := f.listItem()
+	var ret reflect.Value
+	switch item.Type {
+	case StringType:
+		ret = reflect.ValueOf(make([]string, 0, len(items)))
+	case IntegerType:
+		ret = reflect.ValueOf(make([]int64, 0, len(items)))
+	case NumberType:
+		ret = reflect.ValueOf(make([]float64, 0, len(items)))
+	case BooleanType:
+		ret = reflect.ValueOf(make([]bool, 0, len(items)))
+	case DateType, DateTimeType, TimeType:
+		ret = reflect.ValueOf(make([]time.Time, 0, len(items)))
+	default:
+		return nil, fmt.Errorf("invalid list item type: %s", item.Type)
+	}
+	for i := range items {
+		v, err := item.Cast(items[i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid list item %d: %v", i, err)
+		}
+		ret = reflect.Append(ret, reflect.ValueOf(v))
+	}
+	return ret.Interface(), nil
+}
+
+func uncastList(f *Field, in interface{}) (string, error) {
+	v := reflect.ValueOf(in)
+	if v.Kind() != reflect.Slice {
+		return "", fmt.Errorf("invalid list - value:%v type:%T", in, in)
+	}
+	item := f.listItem()
+	items := make([]string, v.Len())
+	for i := range items {
+		s, err := item.Uncast(v.Index(i).Interface())
+		if err != nil {
+			return "", err
+		}
+		items[i] = s
+	}
+	return strings.Join(items, f.listDelimiter()), nil
+}
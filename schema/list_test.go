@@ -0,0 +1,98 @@
+package schema
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestCastList(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		data := []struct {
+			desc  string
+			field Field
+			value string
+			want  interface{}
+		}{
+			{"DefaultItemType", Field{Type: ListType}, "a,b", []string{"a", "b"}},
+			{"Empty", Field{Type: ListType, MissingValues: map[string]struct{}{}}, "", []string{}},
+			{"Integer", Field{Type: ListType, ItemType: IntegerType, Delimiter: ";"}, "1;2;3", []int64{1, 2, 3}},
+			{"Number", Field{Type: ListType, ItemType: NumberType, Delimiter: ";", DecimalChar: ","}, "1,5;2", []float64{1.5, 2}},
+			{"Boolean", Field{Type: ListType, ItemType: BooleanType, TrueValues: []string{"y"}, FalseValues: []string{"n"}}, "y,n", []bool{true, false}},
+			{"Date", Field{Type: ListType, ItemType: DateType, Delimiter: "|"}, "2020-01-02|2021-03-04",
+				[]time.Time{time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC), time.Date(2021, 3, 4, 0, 0, 0, 0, time.UTC)}},
+			{"ItemConstraints", Field{Type: ListType, ItemType: IntegerType, Constraints: Constraints{Minimum: "1", Maximum: "3", MaxLength: 3}}, "1,3", []int64{1, 3}},
+		}
+		for _, d := range data {
+			t.Run(d.desc, func(t *testing.T) {
+				is := is.New(t)
+				got, err := d.field.Cast(d.value)
+				is.NoErr(err)
+				is.Equal(got, d.want)
+			})
+		}
+	})
+	t.Run("Error", func(t *testing.T) {
+		data := []struct {
+			desc  string
+			field Field
+			value string
+		}{
+			{"InvalidItem", Field{Type: ListType, ItemType: IntegerType}, "1,a"},
+			{"InvalidItemType", Field{Type: ListType, ItemType: ObjectType}, "{}"},
+			{"Maximum", Field{Type: ListType, ItemType: IntegerType, Constraints: Constraints{Maximum: "2"}}, "1,3"},
+			{"MinLength", Field{Type: ListType, Constraints: Constraints{MinLength: 3}}, "a,b"},
+			{"MaxLength", Field{Type: ListType, Constraints: Constraints{MaxLength: 1}}, "a,b"},
+		}
+		for _, d := range data {
+			t.Run(d.desc, func(t *testing.T) {
+				is := is.New(t)
+				_, err := d.field.Cast(d.value)
+				is.True(err != nil)
+			})
+		}
+	})
+	t.Run("Enum", func(t *testing.T) {
+		is := is.New(t)
+		var f Field
+		is.NoErr(json.Unmarshal([]byte(`{"name":"tags","type":"list","delimiter":";","constraints":{"enum":["a","b"]}}`), &f))
+		got, err := f.Cast("a;b;a")
+		is.NoErr(err)
+		is.Equal(got, []string{"a", "b", "a"})
+
+		_, err = f.Cast("a;c")
+		is.True(err != nil)
+	})
+}
+
+func TestUncastList(t *testing.T) {
+	data := []struct {
+		desc  string
+		field Field
+		value interface{}
+		want  string
+	}{
+		{"String", Field{Type: ListType, Delimiter: ";"}, []string{"a", "b"}, "a;b"},
+		{"Integer", Field{Type: ListType, ItemType: IntegerType}, []int64{1, 2}, "1,2"},
+		{"IntSlice", Field{Type: ListType, ItemType: IntegerType}, []int{1, 2}, "1,2"},
+		{"Empty", Field{Type: ListType}, []string{}, ""},
+	}
+	for _, d := range data {
+		t.Run(d.desc, func(t *testing.T) {
+			is := is.New(t)
+			got, err := d.field.Uncast(d.value)
+			is.NoErr(err)
+			is.Equal(got, d.want)
+		})
+	}
+	t.Run("Error", func(t *testing.T) {
+		is := is.New(t)
+		f := Field{Type: ListType, ItemType: IntegerType}
+		_, err := f.Uncast("1,2")
+		is.True(err != nil)
+		_, err = f.Uncast([]string{"a"})
+		is.True(err != nil)
+	})
+}
@@ -0,0 +1,486 @@
+package schema
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// MergeReport describes how Merge reconciled the schemas.
+type MergeReport struct {
+	// Widenings lists the field types widened to accept the values of other schemas.
+	Widenings []Widening
+	// Conflicts lists the differences that could not be reconciled.
+	Conflicts []MergeConflict
+}
+
+// Widening describes a field type widened by Merge.
+type Widening struct {
+	// Field is the name of the widened field.
+	Field string
+	// Schema is the index of the schema whose field caused the widening.
+	Schema int
+	// From and To are the field types before and after the widening.
+	From, To FieldType
+}
+
+func (w Widening) String() string {
+	return fmt.Sprintf("field %q: type widened from %s to %s by schema %d", w.Field, w.From, w.To, w.Schema)
+}
+
+// MergeConflict describes a property that Merge could not reconcile. The merged
+// schema keeps the value of the first schema declaring the property.
+type MergeConflict struct {
+	// Field is the name of the field. It is empty for schema-level properties.
+	Field string
+	// Property is the descriptor property, for instance "format".
+	Property string
+	// Schema is the index of the schema which declares Value.
+	Schema int
+	// Value is the property declared by Schema and Merged the property kept in the
+	// merged schema, encoded as strings.
+	Value, Merged string
+}
+
+func (c MergeConflict) String() string {
+	var b strings.Builder
+	if c.Field != "" {
+		fmt.Fprintf(&b, "field %q: ", c.Field)
+	}
+	fmt.Fprintf(&b, "%s %q of schema %d conflicts with %q", c.Property, c.Value, c.Schema, c.Merged)
+	return b.String()
+}
+
+// fieldOccurrence is a field of one of the merged schemas.
+type fieldOccurrence struct {
+	schema int
+	field  *Field
+}
+
+type merger struct {
+	schemas []*Schema
+	report  *MergeReport
+}
+
+func (m *merger) conflict(field, property string, schema int, value, merged interface{}) {
+	m.report.Conflicts = append(m.report.Conflicts, MergeConflict{
+		Field:    field,
+		Property: property,
+		Schema:   schema,
+		Value:    fmt.Sprint(value),
+		Merged:   fmt.Sprint(merged),
+	})
+}
+
+// Merge returns a schema which accepts the data valid against any of the schemas, for
+// instance, the schemas inferred from the files a dataset is partitioned into.
+//
+// Fields are matched by name and declared in the order they first appear. Conflicting
+// field types are widened following the implicit casts used by InferImplicitCasting
+// (for instance, integer and number are merged into number), or to string. Constraints
+// are loosened: bounds, lengths and enum values are extended, and constraints not
+// declared by all schemas (along with required and unique, for fields which are not
+// in all schemas) are dropped. The primary key and foreign keys are kept if all
+// schemas declare them. Missing values, boolean values and categories are united.
+//
+// Properties that can not be reconciled, like different number decimal chars or
+// date formats, are listed by the report and the merged schema keeps the value of
+// the first schema.
+func Merge(schemas ...*Schema) (*Schema, *MergeReport) {
+	m := merger{schemas: schemas, report: &MergeReport{}}
+	var names []string
+	occurrences := make(map[string][]fieldOccurrence)
+	for i, s := range schemas {
+		for j := range s.Fields {
+			f := &s.Fields[j]
+			if _, ok := occurrences[f.Name]; !ok {
+				names = append(names, f.Name)
+			}
+			occurrences[f.Name] = append(occurrences[f.Name], fieldOccurrence{i, f})
+		}
+	}
+	merged := &Schema{}
+	merged.MissingValues, merged.MissingValueLabels = m.missingValues()
+	for _, name := range names {
+		merged.Fields = append(merged.Fields, m.field(name, occurrences[name]))
+	}
+	merged.PrimaryKeys, merged.ForeignKeys = m.keys()
+	if len(schemas) > 0 {
+		merged.RaggedRows = schemas[0].RaggedRows
+	}
+	merged.propagateMissingValues()
+	return merged, m.report
+}
+
+// mergeTypes returns the narrowest type accepting the values of both types.
+func mergeTypes(a, b FieldType) FieldType {
+	switch {
+	case a == b:
+		return a
+	case a == AnyType || b == AnyType:
+		return AnyType
+	}
+	return joinTypes(a, b)
+}
+
+func (m *merger) field(name string, occ []fieldOccurrence) Field {
+	t := fieldType(occ[0].field)
+	for _, o := range occ[1:] {
+		if nt := mergeTypes(t, fieldType(o.field)); nt != t {
+			m.report.Widenings = append(m.report.Widenings, Widening{Field: name, Schema: o.schema, From: t, To: nt})
+			t = nt
+		}
+	}
+	// Type-specific properties come from the fields of the merged type.
+	var same []fieldOccurrence
+	for _, o := range occ {
+		if fieldType(o.field) == t {
+			same = append(same, o)
+		}
+	}
+	f := Field{Name: name, Type: t}
+	if len(same) > 0 {
+		f = *same[0].field
+		if f.Type == "" {
+			f.Type = t
+		}
+	}
+	for _, o := range occ {
+		if f.Title == "" {
+			f.Title = o.field.Title
+		}
+		if f.Description == "" {
+			f.Description = o.field.Description
+		}
+	}
+	m.mergeFormat(&f, occ)
+	if len(same) > 1 {
+		m.mergeProperties(&f, same)
+	}
+	m.mergeConstraints(&f, occ)
+	m.mergeFieldMissingValues(&f, occ)
+	if err := f.compile(); err != nil {
+		// Enum values which do not fit the merged type are dropped, like bounds.
+		f.Constraints.Enum = nil
+		f.compile()
+	}
+	return f
+}
+
+func (m *merger) mergeFormat(f *Field, occ []fieldOccurrence) {
+	t, format := fieldType(f), ""
+	for _, o := range occ {
+		ofmt := fieldFormat(o.field)
+		if fieldType(o.field) != t {
+			// Formats of other types do not apply, strings accept all values anyway.
+			if ofmt != defaultFieldFormat && t != StringType {
+				m.conflict(f.Name, "format", o.schema, ofmt, fieldFormat(f))
+			}
+			continue
+		}
+		switch {
+		case format == "":
+			format = ofmt
+		case ofmt == format:
+		case t == StringType && ofmt != stringBinary && format != stringBinary:
+			// The default format accepts all strings.
+			format = defaultFieldFormat
+		default:
+			m.conflict(f.Name, "format", o.schema, ofmt, format)
+		}
+	}
+	if format == "" {
+		format = defaultFieldFormat
+	}
+	if format != fieldFormat(f) {
+		f.Format = format
+	}
+}
+
+// mergeProperties merges the type-specific properties of the fields of the merged type.
+func (m *merger) mergeProperties(f *Field, same []fieldOccurrence) {
+	for _, o := range same[1:] {
+		of := o.field
+		for _, p := range []struct {
+			name     string
+			got, new interface{}
+		}{
+			{"decimalChar", nonDefault(f.DecimalChar, defaultDecimalChar), nonDefault(of.DecimalChar, defaultDecimalChar)},
+			{"groupChar", nonDefault(f.GroupChar, defaultGroupChar), nonDefault(of.GroupChar, defaultGroupChar)},
+			{"delimiter", f.listDelimiter(), of.listDelimiter()},
+			{"categoriesOrdered", f.CategoriesOrdered, of.CategoriesOrdered},
+			{"arrayItem", f.ArrayItem, of.ArrayItem},
+			{"properties", f.Properties, of.Properties},
+		} {
+			if !reflect.DeepEqual(p.got, p.new) {
+				m.conflict(f.Name, p.name, o.schema, p.new, p.got)
+			}
+		}
+		// Stripping non-numeric chars accepts more values.
+		f.BareNumber = f.BareNumber && of.BareNumber
+		f.TrueValues = unionStrings(f.TrueValues, of.TrueValues)
+		f.FalseValues = unionStrings(f.FalseValues, of.FalseValues)
+		if !reflect.DeepEqual(f.UUIDVersions, of.UUIDVersions) {
+			f.UUIDVersions = unionVersions(f.UUIDVersions, of.UUIDVersions)
+		}
+		if f.Type == ListType {
+			f.ItemType = mergeTypes(nonDefaultType(f.ItemType), nonDefaultType(of.ItemType))
+		}
+		f.Categories = m.unionCategories(f, o)
+	}
+}
+
+func nonDefault(v, def string) string {
+	if v == "" {
+		return def
+	}
+	return v
+}
+
+func nonDefaultType(t FieldType) FieldType {
+	if t == "" {
+		return defaultListItemType
+	}
+	return t
+}
+
+// unionStrings returns the values of a followed by the values of b which are not in a.
+func unionStrings(a, b []string) []string {
+	if reflect.DeepEqual(a, b) {
+		return a
+	}
+	ret := append([]string(nil), a...)
+	for _, v := range b {
+		found := false
+		for _, r := range ret {
+			if r == v {
+				found = true
+				break
+			}
+		}
+		if !found {
+			ret = append(ret, v)
+		}
+	}
+	return ret
+}
+
+func unionVersions(a, b []int) []int {
+	set := make(map[int]struct{})
+	for _, versions := range [][]int{a, b} {
+		if len(versions) == 0 {
+			versions = []int{stringUUIDVersion}
+		}
+		for _, v := range versions {
+			set[v] = struct{}{}
+		}
+	}
+	ret := make([]int, 0, len(set))
+	for v := range set {
+		ret = append(ret, v)
+	}
+	sort.Ints(ret)
+	return ret
+}
+
+func (m *merger) unionCategories(f *Field, o fieldOccurrence) []Category {
+	ret := append([]Category(nil), f.Categories...)
+	for _, c := range o.field.Categories {
+		i, ok := f.category(c.raw())
+		switch {
+		case !ok:
+			ret = append(ret, c)
+		case f.Categories[i].Label != c.Label:
+			m.conflict(f.Name, "categories", o.schema, c, f.Categories[i])
+		}
+	}
+	return ret
+}
+
+// inAllSchemas returns true if all schemas declare the field.
+func (m *merger) inAllSchemas(occ []fieldOccurrence) bool {
+	seen := make(map[int]struct{}, len(occ))
+	for _, o := range occ {
+		seen[o.schema] = struct{}{}
+	}
+	return len(seen) == len(m.schemas)
+}
+
+func (m *merger) mergeConstraints(f *Field, occ []fieldOccurrence) {
+	all := m.inAllSchemas(occ)
+	c := Constraints{Required: all, Unique: all, Pattern: occ[0].field.Constraints.Pattern}
+	for _, o := range occ {
+		oc := o.field.Constraints
+		c.Required = c.Required && oc.Required
+		c.Unique = c.Unique && oc.Unique
+		if oc.Pattern != c.Pattern {
+			c.Pattern = ""
+		}
+	}
+	caster := f.boundCaster()
+	// Bounds are plain numbers.
+	caster.BareNumber = true
+	for _, b := range []struct {
+		bound func(c *Constraints) *string
+		lower bool
+	}{
+		{func(c *Constraints) *string { return &c.Minimum }, true},
+		{func(c *Constraints) *string { return &c.ExclusiveMinimum }, true},
+		{func(c *Constraints) *string { return &c.Maximum }, false},
+		{func(c *Constraints) *string { return &c.ExclusiveMaximum }, false},
+	} {
+		loosest := *b.bound(&occ[0].field.Constraints)
+		for _, o := range occ {
+			v := *b.bound(&o.field.Constraints)
+			if v == "" || loosest == "" {
+				loosest = ""
+				break
+			}
+			cmp, ok := compareBounds(&caster, v, loosest)
+			if !ok {
+				// Bounds which do not fit the merged type are dropped.
+				loosest = ""
+				break
+			}
+			if cmp < 0 == b.lower && cmp != 0 {
+				loosest = v
+			}
+		}
+		*b.bound(&c) = loosest
+	}
+	c.MinLength, c.MaxLength = occ[0].field.Constraints.MinLength, occ[0].field.Constraints.MaxLength
+	enum := occ[0].field.Constraints.Enum
+	for _, o := range occ[1:] {
+		oc := o.field.Constraints
+		if oc.MinLength < c.MinLength {
+			c.MinLength = oc.MinLength
+		}
+		if oc.MaxLength == 0 || c.MaxLength == 0 {
+			c.MaxLength = 0
+		} else if oc.MaxLength > c.MaxLength {
+			c.MaxLength = oc.MaxLength
+		}
+		if len(oc.Enum) == 0 || len(enum) == 0 {
+			enum = nil
+			continue
+		}
+		enum = unionEnum(enum, oc.Enum)
+	}
+	c.Enum = enum
+	f.Constraints = c
+}
+
+func unionEnum(a, b []interface{}) []interface{} {
+	ret := append([]interface{}(nil), a...)
+	seen := make(map[string]struct{}, len(a)+len(b))
+	for _, v := range a {
+		seen[fmt.Sprint(v)] = struct{}{}
+	}
+	for _, v := range b {
+		if _, ok := seen[fmt.Sprint(v)]; !ok {
+			seen[fmt.Sprint(v)] = struct{}{}
+			ret = append(ret, v)
+		}
+	}
+	return ret
+}
+
+// schemaMissingValues returns the schema-level missing values in effect.
+func schemaMissingValues(s *Schema) []string {
+	if s.MissingValues == nil {
+		return []string{defaultMissingValue}
+	}
+	return s.MissingValues
+}
+
+// unionLabels adds the labels to merged, reporting labels that conflict.
+func (m *merger) unionLabels(field string, schema int, merged, labels map[string]string) map[string]string {
+	for v, l := range labels {
+		switch ml, ok := merged[v]; {
+		case !ok:
+			if merged == nil {
+				merged = make(map[string]string)
+			}
+			merged[v] = l
+		case ml != l:
+			m.conflict(field, "missingValues", schema, fmt.Sprintf("%s:%s", v, l), fmt.Sprintf("%s:%s", v, ml))
+		}
+	}
+	return merged
+}
+
+func (m *merger) missingValues() ([]string, map[string]string) {
+	declared := false
+	for _, s := range m.schemas {
+		declared = declared || s.MissingValues != nil
+	}
+	if !declared {
+		return nil, nil
+	}
+	var values []string
+	var labels map[string]string
+	for i, s := range m.schemas {
+		values = unionStrings(values, schemaMissingValues(s))
+		labels = m.unionLabels("", i, labels, s.MissingValueLabels)
+	}
+	return values, labels
+}
+
+// mergeFieldMissingValues unites the missing values of fields declaring their own.
+// Other fields use the schema-level missing values.
+func (m *merger) mergeFieldMissingValues(f *Field, occ []fieldOccurrence) {
+	f.MissingValues, f.MissingValueLabels, f.MissingValuesPlaceholder = nil, nil, nil
+	declared := false
+	for _, o := range occ {
+		declared = declared || o.field.MissingValuesPlaceholder != nil
+	}
+	if !declared {
+		return
+	}
+	var values []string
+	var labels map[string]string
+	for _, o := range occ {
+		s := m.schemas[o.schema]
+		own, ownLabels, err := parseMissingValues(o.field.MissingValuesPlaceholder)
+		switch {
+		case err == nil && own != nil:
+			values = unionStrings(values, own)
+			labels = m.unionLabels(f.Name, o.schema, labels, ownLabels)
+		default:
+			values = unionStrings(values, schemaMissingValues(s))
+			labels = m.unionLabels(f.Name, o.schema, labels, s.MissingValueLabels)
+		}
+	}
+	f.MissingValues = make(map[string]struct{}, len(values))
+	for _, v := range values {
+		f.MissingValues[v] = struct{}{}
+	}
+	f.MissingValueLabels = labels
+	f.MissingValuesPlaceholder = missingValuesPlaceholder(values, labels)
+}
+
+// keys returns the primary and foreign keys declared by all schemas.
+func (m *merger) keys() ([]string, []ForeignKeys) {
+	if len(m.schemas) == 0 {
+		return nil, nil
+	}
+	pk := m.schemas[0].PrimaryKeys
+	fks := m.schemas[0].ForeignKeys
+	for _, s := range m.schemas[1:] {
+		if !reflect.DeepEqual(nonNil(pk), nonNil(s.PrimaryKeys)) {
+			pk = nil
+		}
+		var common []ForeignKeys
+		for _, fk := range fks {
+			for _, sfk := range s.ForeignKeys {
+				if reflect.DeepEqual(fk.Fields, sfk.Fields) && fk.Reference.Resource == sfk.Reference.Resource && reflect.DeepEqual(fk.Reference.Fields, sfk.Reference.Fields) {
+					common = append(common, fk)
+					break
+				}
+			}
+		}
+		fks = common
+	}
+	return append([]string(nil), pk...), append([]ForeignKeys(nil), fks...)
+}
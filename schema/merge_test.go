@@ -0,0 +1,146 @@
+package schema
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestMerge(t *testing.T) {
+	t.Run("UnionAndWidening", func(t *testing.T) {
+		is := is.New(t)
+		jan := &Schema{
+			Fields: []Field{
+				{Name: "id", Type: IntegerType, Constraints: Constraints{Required: true, Unique: true, Minimum: "1", Maximum: "10"}},
+				{Name: "amount", Type: IntegerType, Constraints: Constraints{Minimum: "0"}},
+				{Name: "when", Type: DateType},
+			},
+			PrimaryKeys: []string{"id"},
+		}
+		feb := &Schema{
+			Fields: []Field{
+				{Name: "id", Type: IntegerType, Constraints: Constraints{Required: true, Unique: true, Minimum: "5", Maximum: "20"}},
+				{Name: "amount", Type: NumberType, Constraints: Constraints{Minimum: "-1.5"}},
+				{Name: "when", Type: DateTimeType},
+				{Name: "comment", Type: StringType, Constraints: Constraints{Required: true}},
+			},
+			PrimaryKeys: []string{"id"},
+		}
+		s, r := Merge(jan, feb)
+		is.Equal(len(s.Fields), 4)
+		id, amount, when, comment := s.Fields[0], s.Fields[1], s.Fields[2], s.Fields[3]
+		is.Equal(id.Type, IntegerType)
+		is.True(id.Constraints.Required)
+		is.True(id.Constraints.Unique)
+		is.Equal(id.Constraints.Minimum, "1")
+		is.Equal(id.Constraints.Maximum, "20")
+		is.Equal(amount.Type, NumberType)
+		is.Equal(amount.Constraints.Minimum, "-1.5")
+		is.Equal(when.Type, DateTimeType)
+		is.Equal(comment.Name, "comment")
+		is.True(!comment.Constraints.Required) // Not in all schemas.
+		is.Equal(s.PrimaryKeys, []string{"id"})
+		want := []Widening{
+			{Field: "amount", Schema: 1, From: IntegerType, To: NumberType},
+			{Field: "when", Schema: 1, From: DateType, To: DateTimeType},
+		}
+		if !reflect.DeepEqual(r.Widenings, want) {
+			t.Fatalf("got:%v want:%v", r.Widenings, want)
+		}
+		is.Equal(len(r.Conflicts), 0)
+		is.NoErr(s.Validate())
+	})
+	t.Run("WideningToString", func(t *testing.T) {
+		is := is.New(t)
+		s, r := Merge(
+			&Schema{Fields: []Field{{Name: "a", Type: IntegerType, Constraints: Constraints{Maximum: "10"}}}},
+			&Schema{Fields: []Field{{Name: "a", Type: DateType}}},
+			&Schema{Fields: []Field{{Name: "a", Type: NumberType}}})
+		is.Equal(s.Fields[0].Type, StringType)
+		is.Equal(s.Fields[0].Constraints, Constraints{})
+		is.Equal(len(r.Widenings), 1)
+		is.Equal(r.Widenings[0].To, StringType)
+	})
+	t.Run("LoosenConstraints", func(t *testing.T) {
+		is := is.New(t)
+		s, _ := Merge(
+			&Schema{Fields: []Field{{Name: "a", Type: StringType, Constraints: Constraints{MinLength: 2, MaxLength: 4, Pattern: "[a-z]+", Enum: []interface{}{"ab", "cd"}}}}},
+			&Schema{Fields: []Field{{Name: "a", Type: StringType, Constraints: Constraints{MinLength: 1, MaxLength: 6, Enum: []interface{}{"cd", "ef"}}}}})
+		c := s.Fields[0].Constraints
+		is.Equal(c.MinLength, 1)
+		is.Equal(c.MaxLength, 6)
+		is.Equal(c.Pattern, "")
+		is.Equal(c.Enum, []interface{}{"ab", "cd", "ef"})
+		_, err := s.Fields[0].Cast("ef")
+		is.NoErr(err)
+	})
+	t.Run("Formats", func(t *testing.T) {
+		is := is.New(t)
+		s, r := Merge(
+			&Schema{Fields: []Field{{Name: "email", Type: StringType, Format: stringEmail}, {Name: "d", Type: DateType, Format: "%d/%m/%Y"}}},
+			&Schema{Fields: []Field{{Name: "email", Type: StringType, Format: stringURI}, {Name: "d", Type: DateType, Format: "%m/%d/%Y"}}})
+		is.Equal(s.Fields[0].Format, defaultFieldFormat)
+		is.Equal(s.Fields[1].Format, "%d/%m/%Y")
+		is.Equal(r.Conflicts, []MergeConflict{{Field: "d", Property: "format", Schema: 1, Value: "%m/%d/%Y", Merged: "%d/%m/%Y"}})
+		is.True(strings.Contains(r.Conflicts[0].String(), `field "d"`))
+	})
+	t.Run("Properties", func(t *testing.T) {
+		is := is.New(t)
+		s, r := Merge(
+			&Schema{Fields: []Field{
+				{Name: "n", Type: NumberType, DecimalChar: ","},
+				{Name: "b", Type: BooleanType, TrueValues: []string{"yes"}, FalseValues: []string{"no"}},
+				{Name: "c", Type: CategoricalType, Categories: []Category{{Value: "a"}, {Value: "b", Label: "B"}}},
+			}},
+			&Schema{Fields: []Field{
+				{Name: "n", Type: NumberType},
+				{Name: "b", Type: BooleanType, TrueValues: []string{"Y"}, FalseValues: []string{"no"}},
+				{Name: "c", Type: CategoricalType, Categories: []Category{{Value: "c"}, {Value: "b", Label: "Bee"}}},
+			}})
+		is.Equal(s.Fields[0].DecimalChar, ",")
+		is.Equal(s.Fields[1].TrueValues, []string{"yes", "Y"})
+		is.Equal(s.Fields[1].FalseValues, []string{"no"})
+		is.Equal(s.Fields[2].Categories, []Category{{Value: "a"}, {Value: "b", Label: "B"}, {Value: "c"}})
+		is.Equal(len(r.Conflicts), 2)
+		is.Equal(r.Conflicts[0].Property, "decimalChar")
+		is.Equal(r.Conflicts[1].Property, "categories")
+	})
+	t.Run("MissingValues", func(t *testing.T) {
+		is := is.New(t)
+		a := &Schema{Fields: []Field{{Name: "a", Type: IntegerType}}, MissingValues: []string{"NA"}}
+		b := &Schema{Fields: []Field{{Name: "a", Type: IntegerType}}, MissingValues: []string{"", "-"}}
+		a.propagateMissingValues()
+		b.propagateMissingValues()
+		s, _ := Merge(a, b)
+		is.Equal(s.MissingValues, []string{"NA", "", "-"})
+		_, ok := s.MissingValueLabel("a", "-")
+		is.True(ok)
+	})
+	t.Run("FieldMissingValues", func(t *testing.T) {
+		is := is.New(t)
+		a := &Schema{Fields: []Field{{Name: "a", Type: IntegerType, MissingValuesPlaceholder: []string{"NA"}}}}
+		b := &Schema{Fields: []Field{{Name: "a", Type: IntegerType}}}
+		s, _ := Merge(a, b)
+		is.True(s.MissingValues == nil)
+		is.Equal(s.Fields[0].MissingValuesPlaceholder, []string{"NA", ""})
+		_, ok := s.Fields[0].MissingValueLabel("")
+		is.True(ok)
+	})
+	t.Run("Keys", func(t *testing.T) {
+		is := is.New(t)
+		fk := ForeignKeys{Fields: []string{"a"}, Reference: ForeignKeyReference{Resource: "r", Fields: []string{"id"}}}
+		s, _ := Merge(
+			&Schema{Fields: []Field{{Name: "a"}}, PrimaryKeys: []string{"a"}, ForeignKeys: []ForeignKeys{fk}},
+			&Schema{Fields: []Field{{Name: "a"}}, ForeignKeys: []ForeignKeys{fk}})
+		is.True(s.PrimaryKeys == nil)
+		is.Equal(len(s.ForeignKeys), 1)
+	})
+	t.Run("NoSchemas", func(t *testing.T) {
+		is := is.New(t)
+		s, r := Merge()
+		is.Equal(len(s.Fields), 0)
+		is.Equal(len(r.Widenings), 0)
+	})
+}
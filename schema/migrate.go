@@ -0,0 +1,334 @@
+package schema
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/frictionlessdata/tableschema-go/table"
+)
+
+// NarrowingPolicy defines how Migrate handles fields whose type is narrowed, that is,
+// changed to a type the old values can not be implicitly cast to (for instance,
+// number to integer).
+type NarrowingPolicy int
+
+const (
+	// RejectNarrowing makes Migrate fail if any field type is narrowed. It is the default.
+	RejectNarrowing NarrowingPolicy = iota
+	// FailOnNarrowingError converts the values of narrowed fields. Iterating over the
+	// migrated table fails on the first value that can not be converted.
+	FailOnNarrowingError
+	// ReportNarrowingErrors converts the values of narrowed fields. Values that can not
+	// be converted are replaced by the missing value of the field and reported by
+	// MigratedTable.Report.
+	ReportNarrowingErrors
+)
+
+// MigrationRules configures how Migrate moves data from one schema to another.
+type MigrationRules struct {
+	// Renames maps names of fields in the new schema to the names of the fields they
+	// were renamed from.
+	Renames map[string]string
+	// Defaults maps names of fields added by the new schema to the value of their
+	// cells. Added fields without default are filled with their missing value.
+	Defaults map[string]string
+	// Narrowing defines how narrowing type changes are handled.
+	Narrowing NarrowingPolicy
+}
+
+// MigrationError describes a value that could not be migrated.
+type MigrationError struct {
+	LineNumber int
+	Field      string
+	Value      string
+	Err        error
+}
+
+func (e *MigrationError) Error() string {
+	return fmt.Sprintf("line:%d field:%s value:%q err:%v", e.LineNumber, e.Field, e.Value, e.Err)
+}
+
+// Unwrap returns the error that made the migration of the value fail.
+func (e *MigrationError) Unwrap() error {
+	return e.Err
+}
+
+// columnMigration describes how a column of the migrated table is produced.
+type columnMigration struct {
+	to *Field
+	// from is the field the column is read from, nil if the field was added.
+	from *Field
+	// index is the position of the from field in the old rows.
+	index int
+	// value is the content of the cells of added fields.
+	value string
+	// convert is true if the values must be converted to the new type.
+	convert bool
+	// narrowing is true if the conversion may fail.
+	narrowing bool
+}
+
+// MigratedTable is a table.Table that reads data described by a schema in the layout
+// of another schema. It is created by Migrate.
+type MigratedTable struct {
+	tab     table.Table
+	from    *Schema
+	to      *Schema
+	policy  NarrowingPolicy
+	columns []columnMigration
+	report  []MigrationError
+}
+
+// Migrate returns a table that reads the rows of tab, described by the schema from, in
+// the layout described by the schema to. Old rows are expected to hold their cells in
+// from declaration order, the same expected by Schema.CastRow. Ragged rows are handled
+// as defined by from.RaggedRows.
+//
+// Columns are matched by field name, or by rules.Renames, and reordered as declared in
+// the new schema. Fields added by the new schema are filled with rules.Defaults or
+// their missing value; removed fields are dropped. Values of fields whose type changed
+// are converted, widening conversions (for instance, integer to number or yearmonth to
+// datetime) follow the implicit casts used by Infer, directly or through other types.
+// Narrowing conversions are handled as defined by rules.Narrowing. Missing values of
+// the old fields are replaced by the missing value of the new ones.
+//
+// Migrate returns an error if a required field is added without default or, unless
+// allowed by rules, a field type is narrowed.
+func Migrate(tab table.Table, from, to *Schema, rules MigrationRules) (*MigratedTable, error) {
+	var narrowed []string
+	columns := make([]columnMigration, len(to.Fields))
+	for i := range to.Fields {
+		nf := &to.Fields[i]
+		name := nf.Name
+		if old, ok := rules.Renames[name]; ok {
+			name = old
+		}
+		c := columnMigration{to: nf}
+		of, pos := from.GetField(name)
+		if pos == InvalidPosition {
+			if _, ok := rules.Renames[nf.Name]; ok {
+				return nil, fmt.Errorf("field %s renamed from %s, which is not in the old schema", nf.Name, name)
+			}
+			v, ok := rules.Defaults[nf.Name]
+			if !ok {
+				if to.isRequired(nf) {
+					return nil, fmt.Errorf("required field %s added without default", nf.Name)
+				}
+				v = nf.firstMissingValue(to)
+			}
+			c.value = v
+			columns[i] = c
+			continue
+		}
+		c.from, c.index = of, pos
+		ot, nt := fieldType(of), fieldType(nf)
+		if ot != nt || fieldFormat(of) != fieldFormat(nf) {
+			c.convert = true
+			// Values are safely converted if the new type reads them, like Diff
+			// checks, or if they can be widened.
+			c.narrowing = !readsAs(ot, nt) && !widensTo(ot, nt)
+			if c.narrowing {
+				narrowed = append(narrowed, nf.Name)
+			}
+		}
+		columns[i] = c
+	}
+	if len(narrowed) > 0 && rules.Narrowing == RejectNarrowing {
+		return nil, fmt.Errorf("narrowing type changes of fields: %s", strings.Join(narrowed, ", "))
+	}
+	return &MigratedTable{tab: tab, from: from, to: to, policy: rules.Narrowing, columns: columns}, nil
+}
+
+// Headers returns the names of the fields of the new schema.
+func (t *MigratedTable) Headers() []string {
+	headers := make([]string, len(t.columns))
+	for i, c := range t.columns {
+		headers[i] = c.to.Name
+	}
+	return headers
+}
+
+// Iter returns an iterator over the migrated rows. Each iteration restarts the
+// report of migration errors.
+func (t *MigratedTable) Iter() (table.Iterator, error) {
+	iter, err := t.tab.Iter()
+	if err != nil {
+		return nil, err
+	}
+	t.report = nil
+	return &migratedIterator{t: t, iter: iter, line: -1}, nil
+}
+
+// ReadAll reads all migrated rows.
+func (t *MigratedTable) ReadAll() ([][]string, error) {
+	iter, err := t.Iter()
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+	var rows [][]string
+	for iter.Next() {
+		rows = append(rows, iter.Row())
+	}
+	return rows, iter.Err()
+}
+
+// ReadColumn reads the migrated values of the field named name.
+func (t *MigratedTable) ReadColumn(name string) ([]string, error) {
+	_, index := t.to.GetField(name)
+	if index == InvalidPosition {
+		return nil, fmt.Errorf("column name \"%s\" not found in headers", name)
+	}
+	rows, err := t.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	col := make([]string, len(rows))
+	for i, row := range rows {
+		col[i] = row[index]
+	}
+	return col, nil
+}
+
+// Report returns the values that could not be converted during the last iteration
+// when narrowing errors are reported (see ReportNarrowingErrors).
+func (t *MigratedTable) Report() []MigrationError {
+	return t.report
+}
+
+type migratedIterator struct {
+	t    *MigratedTable
+	iter table.Iterator
+	line int
+	row  []string
+	err  error
+}
+
+func (i *migratedIterator) Next() bool {
+	for i.err == nil && i.iter.Next() {
+		i.line++
+		row, err := i.t.migrateRow(i.line, i.iter.Row())
+		if err != nil {
+			var rerr *RaggedRowError
+			if errors.As(err, &rerr) && rerr.Skipped {
+				continue
+			}
+			i.err = err
+			return false
+		}
+		i.row = row
+		return true
+	}
+	return false
+}
+
+func (i *migratedIterator) Row() []string { return i.row }
+
+func (i *migratedIterator) Err() error {
+	if i.err != nil {
+		return i.err
+	}
+	return i.iter.Err()
+}
+
+func (i *migratedIterator) Close() error { return i.iter.Close() }
+
+func (t *MigratedTable) migrateRow(line int, row []string) ([]string, error) {
+	row, _, err := t.from.normalizeRow(row)
+	if err != nil {
+		var rerr *RaggedRowError
+		if errors.As(err, &rerr) && rerr.Skipped {
+			return nil, err
+		}
+		return nil, fmt.Errorf("line:%d %w", line, err)
+	}
+	ret := make([]string, len(t.columns))
+	for i := range t.columns {
+		c := &t.columns[i]
+		switch {
+		case c.from == nil:
+			ret[i] = c.value
+		case !c.convert:
+			ret[i] = t.migrateMissingValue(c, row[c.index])
+		default:
+			v, err := t.convert(c, row[c.index])
+			if err != nil {
+				merr := MigrationError{LineNumber: line, Field: c.to.Name, Value: row[c.index], Err: err}
+				if !c.narrowing || t.policy != ReportNarrowingErrors {
+					return nil, &merr
+				}
+				t.report = append(t.report, merr)
+				v = c.to.firstMissingValue(t.to)
+			}
+			ret[i] = v
+		}
+	}
+	return ret, nil
+}
+
+// convert converts the cell from the old field to the new one. The converted value
+// is checked against the new field.
+func (t *MigratedTable) convert(c *columnMigration, cell string) (string, error) {
+	if _, ok := c.from.missingValueLabel(cell, t.from); ok {
+		cell = t.migrateMissingValue(c, cell)
+		if _, err := c.to.Cast(cell); err != nil {
+			return "", err
+		}
+		return cell, nil
+	}
+	ret := cell
+	nt := fieldType(c.to)
+	if nt != StringType && nt != AnyType {
+		v, err := c.from.Cast(cell)
+		if err != nil {
+			return "", err
+		}
+		if ret, err = convertValue(v, fieldType(c.from), c.to); err != nil {
+			return "", err
+		}
+	}
+	if _, err := c.to.Cast(ret); err != nil {
+		return "", err
+	}
+	return ret, nil
+}
+
+// migrateMissingValue replaces the cell by the missing value of the new field if it
+// is a missing value of the old one.
+func (t *MigratedTable) migrateMissingValue(c *columnMigration, cell string) string {
+	if _, ok := c.from.missingValueLabel(cell, t.from); ok {
+		return c.to.firstMissingValue(t.to)
+	}
+	return cell
+}
+
+// convertValue encodes the value cast by a field of type from into a cell of the
+// field to.
+func convertValue(v interface{}, from FieldType, to *Field) (string, error) {
+	nt := fieldType(to)
+	switch b := v.(type) {
+	case bool:
+		if nt == IntegerType || nt == NumberType {
+			if b {
+				return "1", nil
+			}
+			return "0", nil
+		}
+	case time.Time:
+		if from == YearType && (nt == IntegerType || nt == NumberType) {
+			return strconv.Itoa(b.Year()), nil
+		}
+		if fieldFormat(to) == defaultFieldFormat {
+			// Uncasting uses RFC3339 for all time types.
+			return encodeBound(nt, b)
+		}
+	case int64, float64:
+		if nt == IntegerType || nt == NumberType {
+			return encodeBound(nt, b)
+		}
+	}
+	return to.Uncast(v)
+}
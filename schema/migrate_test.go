@@ -0,0 +1,196 @@
+package schema
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/frictionlessdata/tableschema-go/table"
+	"github.com/matryer/is"
+)
+
+func TestMigrate(t *testing.T) {
+	data := []struct {
+		Desc    string
+		Old     string
+		New     string
+		Rules   MigrationRules
+		Rows    [][]string
+		Headers []string
+		Want    [][]string
+	}{
+		{"ReorderAndRemove",
+			`{"fields":[{"name":"a"}, {"name":"b"}, {"name":"c"}]}`,
+			`{"fields":[{"name":"c"}, {"name":"a"}]}`,
+			MigrationRules{},
+			[][]string{{"1", "2", "3"}, {"4", "5", "6"}},
+			[]string{"c", "a"},
+			[][]string{{"3", "1"}, {"6", "4"}},
+		},
+		{"Rename",
+			`{"fields":[{"name":"a"}, {"name":"b"}]}`,
+			`{"fields":[{"name":"c"}, {"name":"b"}]}`,
+			MigrationRules{Renames: map[string]string{"c": "a"}},
+			[][]string{{"1", "2"}},
+			[]string{"c", "b"},
+			[][]string{{"1", "2"}},
+		},
+		{"AddedFields",
+			`{"fields":[{"name":"a"}]}`,
+			`{"fields":[{"name":"a"}, {"name":"b", "constraints":{"required":true}}, {"name":"c"}], "missingValues":["NA"]}`,
+			MigrationRules{Defaults: map[string]string{"b": "x"}},
+			[][]string{{"1"}},
+			[]string{"a", "b", "c"},
+			[][]string{{"1", "x", "NA"}},
+		},
+		{"Widening",
+			`{"fields":[{"name":"a", "type":"integer"}, {"name":"b", "type":"date"}, {"name":"c", "type":"boolean"}, {"name":"d", "type":"year"}, {"name":"e", "type":"yearmonth"}, {"name":"f", "type":"number"}]}`,
+			`{"fields":[{"name":"a", "type":"number"}, {"name":"b", "type":"datetime"}, {"name":"c", "type":"integer"}, {"name":"d", "type":"integer"}, {"name":"e", "type":"date"}, {"name":"f", "type":"string"}]}`,
+			MigrationRules{},
+			[][]string{{"10", "2015-01-02", "true", "2016", "2017-03", "1.5"}, {"", "", "", "", "", ""}},
+			[]string{"a", "b", "c", "d", "e", "f"},
+			[][]string{{"10", "2015-01-02T00:00:00Z", "1", "2016", "2017-03-01", "1.5"}, {"", "", "", "", "", ""}},
+		},
+		{"MissingValuesChanged",
+			`{"fields":[{"name":"a", "type":"integer"}], "missingValues":["-"]}`,
+			`{"fields":[{"name":"a", "type":"number"}], "missingValues":["NA"]}`,
+			MigrationRules{},
+			[][]string{{"-"}},
+			[]string{"a"},
+			[][]string{{"NA"}},
+		},
+		{"MissingValuesOfUnchangedFields",
+			`{"fields":[{"name":"a", "type":"integer"}, {"name":"b"}], "missingValues":["NA"]}`,
+			`{"fields":[{"name":"a", "type":"integer"}, {"name":"b"}], "missingValues":["-"]}`,
+			MigrationRules{},
+			[][]string{{"NA", "NA"}, {"1", "x"}},
+			[]string{"a", "b"},
+			[][]string{{"-", "-"}, {"1", "x"}},
+		},
+		{"TwoStepWidening",
+			`{"fields":[{"name":"a", "type":"yearmonth"}, {"name":"b", "type":"boolean"}]}`,
+			`{"fields":[{"name":"a", "type":"datetime"}, {"name":"b", "type":"number"}]}`,
+			MigrationRules{},
+			[][]string{{"2017-03", "false"}},
+			[]string{"a", "b"},
+			[][]string{{"2017-03-01T00:00:00Z", "0"}},
+		},
+		{"Narrowing",
+			`{"fields":[{"name":"a", "type":"number"}, {"name":"b", "type":"datetime"}]}`,
+			`{"fields":[{"name":"a", "type":"integer"}, {"name":"b", "type":"date"}]}`,
+			MigrationRules{Narrowing: FailOnNarrowingError},
+			[][]string{{"2", "2015-01-02T00:00:00Z"}},
+			[]string{"a", "b"},
+			[][]string{{"2", "2015-01-02"}},
+		},
+	}
+	for _, d := range data {
+		t.Run(d.Desc, func(t *testing.T) {
+			is := is.New(t)
+			to := mustReadSchema(t, d.New)
+			mt, err := Migrate(table.FromSlices(nil, d.Rows), mustReadSchema(t, d.Old), to, d.Rules)
+			is.NoErr(err)
+			is.Equal(mt.Headers(), d.Headers)
+			got, err := mt.ReadAll()
+			is.NoErr(err)
+			if !reflect.DeepEqual(got, d.Want) {
+				t.Fatalf("got rows:%v want:%v", got, d.Want)
+			}
+			// Migrated rows are valid according to the new schema.
+			for _, row := range got {
+				for i := range to.Fields {
+					_, err := to.Fields[i].Cast(row[i])
+					is.NoErr(err)
+				}
+			}
+		})
+	}
+	t.Run("ReadColumn", func(t *testing.T) {
+		is := is.New(t)
+		mt, err := Migrate(
+			table.FromSlices(nil, [][]string{{"1", "a"}, {"2", "b"}}),
+			mustReadSchema(t, `{"fields":[{"name":"a", "type":"integer"}, {"name":"b"}]}`),
+			mustReadSchema(t, `{"fields":[{"name":"b"}, {"name":"a", "type":"number"}]}`),
+			MigrationRules{})
+		is.NoErr(err)
+		col, err := mt.ReadColumn("a")
+		is.NoErr(err)
+		is.Equal(col, []string{"1", "2"})
+		_, err = mt.ReadColumn("c")
+		is.True(err != nil)
+	})
+}
+
+func TestMigrate_Error(t *testing.T) {
+	data := []struct {
+		Desc  string
+		Old   string
+		New   string
+		Rules MigrationRules
+	}{
+		{"RequiredFieldWithoutDefault",
+			`{"fields":[{"name":"a"}]}`,
+			`{"fields":[{"name":"a"}, {"name":"b", "constraints":{"required":true}}]}`,
+			MigrationRules{},
+		},
+		{"PrimaryKeyWithoutDefault",
+			`{"fields":[{"name":"a"}]}`,
+			`{"fields":[{"name":"a"}, {"name":"b"}], "primaryKey":"b"}`,
+			MigrationRules{},
+		},
+		{"RenamedFromUnknownField",
+			`{"fields":[{"name":"a"}]}`,
+			`{"fields":[{"name":"b"}]}`,
+			MigrationRules{Renames: map[string]string{"b": "c"}},
+		},
+		{"Narrowing",
+			`{"fields":[{"name":"a", "type":"number"}]}`,
+			`{"fields":[{"name":"a", "type":"integer"}]}`,
+			MigrationRules{},
+		},
+	}
+	for _, d := range data {
+		t.Run(d.Desc, func(t *testing.T) {
+			is := is.New(t)
+			_, err := Migrate(table.FromSlices(nil, nil), mustReadSchema(t, d.Old), mustReadSchema(t, d.New), d.Rules)
+			is.True(err != nil)
+		})
+	}
+}
+
+func TestMigrate_NarrowingErrors(t *testing.T) {
+	old := `{"fields":[{"name":"a", "type":"number"}, {"name":"b"}]}`
+	new := `{"fields":[{"name":"a", "type":"integer"}, {"name":"b"}], "missingValues":["NA"]}`
+	rows := [][]string{{"1", "x"}, {"1.5", "y"}, {"3", "z"}}
+	t.Run("Fail", func(t *testing.T) {
+		is := is.New(t)
+		mt, err := Migrate(table.FromSlices(nil, rows), mustReadSchema(t, old), mustReadSchema(t, new), MigrationRules{Narrowing: FailOnNarrowingError})
+		is.NoErr(err)
+		got, err := mt.ReadAll()
+		var merr *MigrationError
+		is.True(errors.As(err, &merr))
+		is.Equal(merr.LineNumber, 1)
+		is.Equal(merr.Field, "a")
+		is.Equal(merr.Value, "1.5")
+		is.Equal(got, [][]string{{"1", "x"}})
+	})
+	t.Run("Report", func(t *testing.T) {
+		is := is.New(t)
+		mt, err := Migrate(table.FromSlices(nil, rows), mustReadSchema(t, old), mustReadSchema(t, new), MigrationRules{Narrowing: ReportNarrowingErrors})
+		is.NoErr(err)
+		got, err := mt.ReadAll()
+		is.NoErr(err)
+		is.Equal(got, [][]string{{"1", "x"}, {"NA", "y"}, {"3", "z"}})
+		report := mt.Report()
+		is.Equal(len(report), 1)
+		is.Equal(report[0].LineNumber, 1)
+		is.Equal(report[0].Value, "1.5")
+	})
+	t.Run("InvalidRow", func(t *testing.T) {
+		is := is.New(t)
+		mt, err := Migrate(table.FromSlices(nil, [][]string{{"1"}}), mustReadSchema(t, old), mustReadSchema(t, old), MigrationRules{})
+		is.NoErr(err)
+		_, err = mt.ReadAll()
+		is.True(err != nil)
+	})
+}
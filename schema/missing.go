@@ -0,0 +1,184 @@
+package schema
+
+import (
+	"fmt"
+	"sort"
+)
+
+// defaultMissingValue is the missing value used when neither the field nor the
+// schema declare missing values.
+// More at: https://specs.frictionlessdata.io/table-schema/#missing-values
+const defaultMissingValue = ""
+
+// parseMissingValues processes a missingValues declaration, which could either be a list
+// of strings or, as allowed by Table Schema v2, a list of {"value", "label"} objects.
+// It returns the values in declaration order and the labels of the values that have one.
+func parseMissingValues(ph interface{}) ([]string, map[string]string, error) {
+	switch mvs := ph.(type) {
+	case nil:
+		return nil, nil, nil
+	case []string:
+		return mvs, nil, nil
+	case []interface{}:
+		values := make([]string, 0, len(mvs))
+		var labels map[string]string
+		for _, mv := range mvs {
+			switch v := mv.(type) {
+			case string:
+				values = append(values, v)
+			case map[string]interface{}:
+				value, ok := v["value"].(string)
+				if !ok {
+					return nil, nil, fmt.Errorf("missing value must have a string value, got:%v", mv)
+				}
+				values = append(values, value)
+				label, ok := v["label"].(string)
+				if v["label"] != nil && !ok {
+					return nil, nil, fmt.Errorf("missing value label must be a string, got:%v", mv)
+				}
+				if label != "" {
+					if labels == nil {
+						labels = make(map[string]string)
+					}
+					labels[value] = label
+				}
+			default:
+				return nil, nil, fmt.Errorf("missing value must be either a string or an object, got:%v", mv)
+			}
+		}
+		return values, labels, nil
+	}
+	return nil, nil, fmt.Errorf("missingValues must be a list")
+}
+
+// RequiredError is returned when a field which does not allow null values, either
+// because it has the required constraint or because it is part of the primary key,
+// holds a missing value.
+type RequiredError struct {
+	// Field is the name of the required field.
+	Field string
+	// Value is the missing value found.
+	Value string
+}
+
+// Error returns a string version of the error.
+func (e *RequiredError) Error() string {
+	return fmt.Sprintf("field %s is required, got missing value:%q", e.Field, e.Value)
+}
+
+// isRequired returns true if the field does not accept null values. Primary key fields
+// are implicitly required.
+func (s *Schema) isRequired(f *Field) bool {
+	if f.Constraints.Required {
+		return true
+	}
+	for _, pk := range s.PrimaryKeys {
+		if pk == f.Name {
+			return true
+		}
+	}
+	return false
+}
+
+// missingValuesPlaceholder returns the JSON representation of the passed-in missing values.
+// Values are represented as strings, unless they have a label.
+func missingValuesPlaceholder(values []string, labels map[string]string) interface{} {
+	if values == nil {
+		return nil
+	}
+	if len(labels) == 0 {
+		return values
+	}
+	ph := make([]interface{}, len(values))
+	for i, v := range values {
+		ph[i] = v
+		if l, ok := labels[v]; ok {
+			ph[i] = map[string]string{"value": v, "label": l}
+		}
+	}
+	return ph
+}
+
+// missingValueLabel reports whether value represents a null value for the field and
+// returns the label associated to it. Field-level missing values take precedence over
+// the schema-level ones, which take precedence over the default ([""]). The schema
+// might be nil.
+func (f *Field) missingValueLabel(value string, s *Schema) (string, bool) {
+	if f.MissingValues != nil {
+		if _, ok := f.MissingValues[value]; !ok {
+			return "", false
+		}
+		return f.MissingValueLabels[value], true
+	}
+	if s != nil && s.MissingValues != nil {
+		for _, mv := range s.MissingValues {
+			if mv == value {
+				return s.MissingValueLabels[value], true
+			}
+		}
+		return "", false
+	}
+	return "", value == defaultMissingValue
+}
+
+// firstMissingValue returns the value that should be used to represent null
+// values of the field, which is the first missing value in effect.
+func (f *Field) firstMissingValue(s *Schema) string {
+	if f.MissingValues != nil {
+		if values, _, err := parseMissingValues(f.MissingValuesPlaceholder); err == nil && len(values) > 0 {
+			return values[0]
+		}
+		if s != nil && len(s.MissingValues) > 0 {
+			if _, ok := f.MissingValues[s.MissingValues[0]]; ok {
+				return s.MissingValues[0]
+			}
+		}
+		if _, ok := f.MissingValues[defaultMissingValue]; ok || len(f.MissingValues) == 0 {
+			return defaultMissingValue
+		}
+		// No order is known, picking the smallest for the sake of determinism.
+		values := make([]string, 0, len(f.MissingValues))
+		for v := range f.MissingValues {
+			values = append(values, v)
+		}
+		sort.Strings(values)
+		return values[0]
+	}
+	if s != nil && s.MissingValues != nil {
+		if len(s.MissingValues) > 0 {
+			return s.MissingValues[0]
+		}
+	}
+	return defaultMissingValue
+}
+
+// MissingValueLabel reports whether the value represents a null value for the field. If
+// it does, it also returns the label describing why the value is missing, which is empty
+// if the missing value has no label. If the field does not declare missing values, [""]
+// is used.
+func (f *Field) MissingValueLabel(value string) (string, bool) {
+	return f.missingValueLabel(value, nil)
+}
+
+// MissingValueLabel reports whether the value represents a null value for the field
+// named name. If it does, it also returns the label describing why the value is missing.
+// Field-level missing values take precedence over schema-level ones, which default
+// to [""]. It returns false if the schema has no field with the passed-in name.
+func (s *Schema) MissingValueLabel(name, value string) (string, bool) {
+	f, pos := s.GetField(name)
+	if pos == InvalidPosition {
+		return "", false
+	}
+	return f.missingValueLabel(value, s)
+}
+
+// MissingValue returns the value used to represent null values of the field named
+// name, which is the first missing value in effect. It returns false if the schema
+// has no field with the passed-in name.
+func (s *Schema) MissingValue(name string) (string, bool) {
+	f, pos := s.GetField(name)
+	if pos == InvalidPosition {
+		return "", false
+	}
+	return f.firstMissingValue(s), true
+}
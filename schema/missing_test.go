@@ -0,0 +1,268 @@
+package schema
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/frictionlessdata/tableschema-go/table"
+	"github.com/matryer/is"
+)
+
+func TestParseMissingValues(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		data := []struct {
+			desc   string
+			ph     interface{}
+			values []string
+			labels map[string]string
+		}{
+			{"Nil", nil, nil, nil},
+			{"Empty", []interface{}{}, []string{}, nil},
+			{"Strings", []interface{}{"", "NA"}, []string{"", "NA"}, nil},
+			{"StringSlice", []string{"NA"}, []string{"NA"}, nil},
+			{"Labels", []interface{}{"", map[string]interface{}{"value": "-", "label": "Not applicable"}}, []string{"", "-"}, map[string]string{"-": "Not applicable"}},
+		}
+		for _, d := range data {
+			t.Run(d.desc, func(t *testing.T) {
+				is := is.New(t)
+				values, labels, err := parseMissingValues(d.ph)
+				is.NoErr(err)
+				is.True(reflect.DeepEqual(values, d.values))
+				is.True(reflect.DeepEqual(labels, d.labels))
+			})
+		}
+	})
+	t.Run("Error", func(t *testing.T) {
+		data := []struct {
+			desc string
+			ph   interface{}
+		}{
+			{"NotAList", "NA"},
+			{"InvalidEntry", []interface{}{1}},
+			{"InvalidValue", []interface{}{map[string]interface{}{"value": 1}}},
+			{"InvalidLabel", []interface{}{map[string]interface{}{"value": "-", "label": 1}}},
+		}
+		for _, d := range data {
+			t.Run(d.desc, func(t *testing.T) {
+				is := is.New(t)
+				_, _, err := parseMissingValues(d.ph)
+				is.True(err != nil)
+			})
+		}
+	})
+}
+
+func TestMissingValues_Read(t *testing.T) {
+	is := is.New(t)
+	s, err := Read(strings.NewReader(`{
+		"fields":[
+			{"name":"a","type":"integer"},
+			{"name":"b","type":"integer","missingValues":[{"value":"-1","label":"Unknown"}]},
+			{"name":"c","type":"integer","missingValues":[]}
+		],
+		"missingValues":["", {"value":"NA","label":"Not asked"}]
+	}`))
+	is.NoErr(err)
+	is.Equal(s.MissingValues, []string{"", "NA"})
+	is.Equal(s.MissingValueLabels, map[string]string{"NA": "Not asked"})
+
+	// Schema-level missing values.
+	label, ok := s.MissingValueLabel("a", "NA")
+	is.True(ok)
+	is.Equal(label, "Not asked")
+	_, ok = s.MissingValueLabel("a", "-1")
+	is.True(!ok)
+
+	// Field-level missing values override schema-level ones.
+	label, ok = s.MissingValueLabel("b", "-1")
+	is.True(ok)
+	is.Equal(label, "Unknown")
+	_, ok = s.MissingValueLabel("b", "NA")
+	is.True(!ok)
+
+	// Empty list means no missing values.
+	_, ok = s.MissingValueLabel("c", "")
+	is.True(!ok)
+
+	// Unknown field.
+	_, ok = s.MissingValueLabel("d", "")
+	is.True(!ok)
+
+	t.Run("Write", func(t *testing.T) {
+		is := is.New(t)
+		var buf bytes.Buffer
+		is.NoErr(s.Write(&buf))
+		got, err := Read(&buf)
+		is.NoErr(err)
+		is.Equal(got, s)
+	})
+}
+
+func TestMissingValues_Cast(t *testing.T) {
+	t.Run("FieldDefault", func(t *testing.T) {
+		is := is.New(t)
+		f := Field{Name: "a", Type: IntegerType}
+		v, err := f.Cast("")
+		is.NoErr(err)
+		is.Equal(v, nil)
+	})
+	t.Run("StringFieldDefault", func(t *testing.T) {
+		is := is.New(t)
+		f := asJSONField(Field{Name: "Name", Type: StringType})
+		v, err := f.Cast("")
+		is.NoErr(err)
+		is.Equal(v, nil)
+		// Fields declaring no missing values keep empty strings.
+		f = asJSONField(Field{Name: "Name", Type: StringType, MissingValuesPlaceholder: []string{}})
+		v, err = f.Cast("")
+		is.NoErr(err)
+		is.Equal(v, "")
+	})
+	t.Run("CastRow", func(t *testing.T) {
+		is := is.New(t)
+		// Schemas built in code only have schema-level missing values.
+		s := Schema{
+			Fields:        []Field{{Name: "A", Type: IntegerType}, {Name: "B", Type: IntegerType, MissingValues: map[string]struct{}{"-": {}}}},
+			MissingValues: []string{"NA"},
+		}
+		row := struct{ A, B int }{}
+		is.NoErr(s.CastRow([]string{"NA", "-"}, &row))
+		is.True(s.CastRow([]string{"-", "-"}, &row) != nil)   // "-" is only missing for B
+		is.True(s.CastRow([]string{"NA", "NA"}, &row) != nil) // "NA" is not missing for B
+	})
+	t.Run("CastRowDefault", func(t *testing.T) {
+		is := is.New(t)
+		s := Schema{Fields: []Field{{Name: "A", Type: IntegerType}}}
+		row := struct{ A int }{}
+		is.NoErr(s.CastRow([]string{""}, &row))
+		is.Equal(row.A, 0)
+	})
+	t.Run("CastColumn", func(t *testing.T) {
+		is := is.New(t)
+		s := Schema{Fields: []Field{{Name: "A", Type: IntegerType}}, MissingValues: []string{"NA"}}
+		var got []int
+		is.NoErr(s.CastColumn([]string{"1", "NA", "3"}, "A", &got))
+		is.Equal(got, []int{1, 0, 3})
+	})
+}
+
+func TestFirstMissingValue(t *testing.T) {
+	data := []struct {
+		desc   string
+		field  Field
+		schema *Schema
+		want   string
+	}{
+		{"Default", Field{}, nil, ""},
+		{"Schema", Field{}, &Schema{MissingValues: []string{"NA", "-"}}, "NA"},
+		{"SchemaEmpty", Field{}, &Schema{MissingValues: []string{}}, ""},
+		{"FieldDeclared", Field{MissingValuesPlaceholder: []interface{}{"-", "NA"}, MissingValues: map[string]struct{}{"-": {}, "NA": {}}}, &Schema{MissingValues: []string{"NA"}}, "-"},
+		{"FieldCopiedFromSchema", Field{MissingValues: map[string]struct{}{"-": {}, "NA": {}}}, &Schema{MissingValues: []string{"NA", "-"}}, "NA"},
+		{"FieldUnordered", Field{MissingValues: map[string]struct{}{"x": {}, "a": {}}}, nil, "a"},
+	}
+	for _, d := range data {
+		t.Run(d.desc, func(t *testing.T) {
+			is := is.New(t)
+			is.Equal(d.field.firstMissingValue(d.schema), d.want)
+		})
+	}
+}
+
+func TestSchema_MissingValue(t *testing.T) {
+	is := is.New(t)
+	s := Schema{
+		Fields:        []Field{{Name: "a"}, {Name: "b", MissingValuesPlaceholder: []string{"-"}, MissingValues: map[string]struct{}{"-": {}}}},
+		MissingValues: []string{"NA"},
+	}
+	mv, ok := s.MissingValue("a")
+	is.True(ok)
+	is.Equal(mv, "NA")
+	mv, ok = s.MissingValue("b")
+	is.True(ok)
+	is.Equal(mv, "-")
+	_, ok = s.MissingValue("c")
+	is.True(!ok)
+}
+
+func TestSchema_Required(t *testing.T) {
+	s := Schema{
+		Fields: []Field{
+			{Name: "ID", Type: IntegerType},
+			{Name: "Name", Type: StringType, Constraints: Constraints{Required: true}},
+			{Name: "Age", Type: IntegerType},
+		},
+		PrimaryKeys:   []string{"ID"},
+		MissingValues: []string{"", "NA"},
+	}
+	type person struct {
+		ID  int
+		Age *int
+	}
+	t.Run("CastRow", func(t *testing.T) {
+		data := []struct {
+			desc  string
+			row   []string
+			field string
+		}{
+			{"PrimaryKey", []string{"NA", "Foo", "10"}, "ID"},
+			{"RequiredNotMappedToStruct", []string{"1", "", "10"}, "Name"},
+		}
+		for _, d := range data {
+			t.Run(d.desc, func(t *testing.T) {
+				is := is.New(t)
+				var p person
+				err := s.CastRow(d.row, &p)
+				var reqErr *RequiredError
+				is.True(errors.As(err, &reqErr))
+				is.Equal(reqErr.Field, d.field)
+			})
+		}
+		t.Run("OptionalMissing", func(t *testing.T) {
+			is := is.New(t)
+			var p person
+			is.NoErr(s.CastRow([]string{"1", "Foo", "NA"}, &p))
+			is.True(p.Age == nil)
+		})
+	})
+	t.Run("CastTable", func(t *testing.T) {
+		is := is.New(t)
+		tab := table.FromSlices([]string{"ID", "Name", "Age"}, [][]string{{"1", "Foo", "10"}, {"2", "NA", "20"}, {"", "Bar", "30"}})
+		var people []person
+		err := s.CastTable(tab, &people)
+		var cErr *ConversionError
+		is.True(errors.As(err, &cErr))
+		is.Equal(len(cErr.Errors), 2)
+		is.Equal(cErr.Errors[0].LineNumber, 1)
+		is.Equal(cErr.Errors[1].LineNumber, 2)
+		var reqErr *RequiredError
+		is.True(errors.As(cErr.Errors[1], &reqErr))
+		is.Equal(reqErr.Field, "ID")
+		is.Equal(len(people), 1)
+	})
+	t.Run("CastColumn", func(t *testing.T) {
+		is := is.New(t)
+		var ids []int
+		err := s.CastColumn([]string{"1", "NA"}, "ID", &ids)
+		var rowErr RowConversionError
+		is.True(errors.As(err, &rowErr))
+		is.Equal(rowErr.LineNumber, 1)
+		var reqErr *RequiredError
+		is.True(errors.As(err, &reqErr))
+	})
+	t.Run("UncastRow", func(t *testing.T) {
+		is := is.New(t)
+		_, err := s.UncastRow(struct{ ID *int }{})
+		var reqErr *RequiredError
+		is.True(errors.As(err, &reqErr))
+	})
+	t.Run("FieldCast", func(t *testing.T) {
+		is := is.New(t)
+		f := Field{Name: "Name", Type: StringType, Constraints: Constraints{Required: true}}
+		_, err := f.Cast("")
+		var reqErr *RequiredError
+		is.True(errors.As(err, &reqErr))
+	})
+}
@@ -0,0 +1,81 @@
+package schema
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"reflect"
+)
+
+// Null represents a cell that might be missing. It can be used as the type of struct
+// fields passed to Schema.CastRow and Schema.UncastRow. Its behaviour is similar to
+// sql.NullString and friends, which are also supported, but it holds values of any
+// schema type and keeps the label of the missing value.
+type Null struct {
+	// Value holds the cast value, if the cell is not missing.
+	Value interface{}
+	// Valid is true if the cell is not missing.
+	Valid bool
+	// Label holds the label of the missing value, if the cell is missing and its
+	// missing value has a label.
+	Label string
+}
+
+// Scan implements the sql.Scanner interface.
+func (n *Null) Scan(value interface{}) error {
+	n.Value, n.Valid, n.Label = value, value != nil, ""
+	return nil
+}
+
+var (
+	scannerType = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+	valuerType  = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+	nullType    = reflect.TypeOf(Null{})
+)
+
+// isNullable returns true if the type is Null or a sql.Scanner implementation.
+func isNullable(t reflect.Type) bool {
+	return t == nullType || isScanner(t)
+}
+
+// isScanner returns true if values of the type are set through sql.Scanner.
+func isScanner(t reflect.Type) bool {
+	return t.Kind() != reflect.Ptr && reflect.PtrTo(t).Implements(scannerType)
+}
+
+// setNull sets the struct field as null, for instance, because the cell is missing.
+// Pointer fields are set to nil, Null fields are invalidated and keep the label
+// and sql.Scanner fields are scanned from nil. Other fields are left untouched.
+func (s *structField) setNull(label string) error {
+	switch {
+	case s.Type == nullType:
+		s.value.Set(reflect.ValueOf(Null{Label: label}))
+	case s.Type.Kind() == reflect.Ptr:
+		s.value.Set(reflect.Zero(s.Type))
+	case isScanner(s.Type):
+		return s.value.Addr().Interface().(sql.Scanner).Scan(nil)
+	}
+	return nil
+}
+
+// nullableValue unwraps nullable values (nil pointers, Null, and driver.Valuer
+// implementations like sql.NullInt64). It returns false if the value is null.
+func nullableValue(v reflect.Value) (interface{}, bool, error) {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil, false, nil
+		}
+		v = v.Elem()
+	}
+	switch {
+	case v.Type() == nullType:
+		n := v.Interface().(Null)
+		return n.Value, n.Valid && n.Value != nil, nil
+	case v.Type().Implements(valuerType):
+		val, err := v.Interface().(driver.Valuer).Value()
+		if err != nil {
+			return nil, false, err
+		}
+		return val, val != nil, nil
+	}
+	return v.Interface(), true, nil
+}
@@ -0,0 +1,85 @@
+package schema
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestSchema_CastRowNull(t *testing.T) {
+	s := Schema{
+		Fields: []Field{
+			{Name: "Age", Type: IntegerType},
+			{Name: "Name", Type: StringType},
+			{Name: "Born", Type: DateType},
+		},
+		MissingValues:      []string{"", "NA"},
+		MissingValueLabels: map[string]string{"NA": "not answered"},
+	}
+	t.Run("Pointers", func(t *testing.T) {
+		is := is.New(t)
+		var row struct {
+			Age  *int64
+			Name *string
+			Born *time.Time
+		}
+		is.NoErr(s.CastRow([]string{"NA", "Foo", ""}, &row))
+		is.True(row.Age == nil)
+		is.Equal(*row.Name, "Foo")
+		is.True(row.Born == nil)
+	})
+	t.Run("SQLNullTypes", func(t *testing.T) {
+		is := is.New(t)
+		var row struct {
+			Age  sql.NullInt64
+			Name sql.NullString
+			Born sql.NullTime
+		}
+		is.NoErr(s.CastRow([]string{"42", "NA", "2015-10-15"}, &row))
+		is.Equal(row.Age, sql.NullInt64{Int64: 42, Valid: true})
+		is.Equal(row.Name, sql.NullString{})
+		is.Equal(row.Born, sql.NullTime{Time: time.Date(2015, time.October, 15, 0, 0, 0, 0, time.UTC), Valid: true})
+	})
+	t.Run("Null", func(t *testing.T) {
+		is := is.New(t)
+		var row struct {
+			Age  Null
+			Name *Null
+		}
+		is.NoErr(s.CastRow([]string{"NA", "Foo", ""}, &row))
+		is.Equal(row.Age, Null{Label: "not answered"})
+		is.Equal(*row.Name, Null{Value: "Foo", Valid: true})
+	})
+}
+
+func TestSchema_UncastRowNull(t *testing.T) {
+	s := Schema{
+		Fields:        []Field{{Name: "Age", Type: IntegerType}, {Name: "Name", Type: StringType}, {Name: "Score", Type: NumberType}},
+		MissingValues: []string{"NA", ""},
+	}
+	t.Run("NullValues", func(t *testing.T) {
+		is := is.New(t)
+		row := struct {
+			Age   *int
+			Name  sql.NullString
+			Score Null
+		}{}
+		got, err := s.UncastRow(row)
+		is.NoErr(err)
+		is.Equal(got, []string{"NA", "NA", "NA"})
+	})
+	t.Run("ValidValues", func(t *testing.T) {
+		is := is.New(t)
+		age := 42
+		row := struct {
+			Age   *int
+			Name  sql.NullString
+			Score Null
+		}{&age, sql.NullString{String: "Foo", Valid: true}, Null{Value: 1.5, Valid: true}}
+		got, err := s.UncastRow(row)
+		is.NoErr(err)
+		is.Equal(got, []string{"42", "Foo", "1.5"})
+	})
+}
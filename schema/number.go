@@ -12,12 +12,16 @@ func castNumber(decimalChar, groupChar string, bareNumber bool, value string, c
 	if decimalChar != "" {
 		dc = decimalChar
 	}
-	v := strings.Replace(value, dc, ".", 1)
 	gc := defaultGroupChar
 	if groupChar != "" {
 		gc = groupChar
 	}
-	v = strings.Replace(v, gc, "", -1)
+	// Group chars are removed first, as they might be "." (for instance, 1.234,56).
+	v := value
+	if gc != dc {
+		v = strings.Replace(v, gc, "", -1)
+	}
+	v = strings.Replace(v, dc, ".", 1)
 	if !bareNumber {
 		var err error
 		v, err = stripNumberFromString(v)
@@ -29,23 +33,21 @@ func castNumber(decimalChar, groupChar string, bareNumber bool, value string, c
 	if err != nil {
 		return 0, err
 	}
-	if c.Maximum != "" {
-		max, err := strconv.ParseFloat(c.Maximum, 64)
-		if err != nil {
-			return 0, fmt.Errorf("invalid maximum number: %v", c.Maximum)
-		}
-		if returned > max {
-			return 0, fmt.Errorf("constraint check error: integer:%f > maximum:%f", returned, max)
-		}
-	}
-	if c.Minimum != "" {
-		min, err := strconv.ParseFloat(c.Minimum, 64)
-		if err != nil {
-			return 0, fmt.Errorf("invalid minimum integer: %v", c.Minimum)
-		}
-		if returned < min {
-			return 0, fmt.Errorf("constraint check error: integer:%f < minimum:%f", returned, min)
+	err = checkBounds(NumberType, returned, c, func(bound string) (int, error) {
+		// Bounds are encoded like values, using the same decimal and group chars.
+		b, err := castNumber(decimalChar, groupChar, true, bound, Constraints{})
+		switch {
+		case err != nil:
+			return 0, err
+		case returned < b:
+			return -1, nil
+		case returned > b:
+			return 1, nil
 		}
+		return 0, nil
+	})
+	if err != nil {
+		return 0, err
 	}
 	return returned, nil
 }
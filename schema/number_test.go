@@ -31,6 +31,7 @@ func TestCastNumber(t *testing.T) {
 			{"DecimalChar", "95;10", 95.10, ";", defaultGroupChar, defaultBareNumber},
 			{"DecimalCharDefault", "95.10", 95.10, "", defaultGroupChar, defaultBareNumber},
 			{"Mix", "EUR 95;10", 95.10, ";", ";", notBareNumber},
+			{"DotGroupChar", "1.234.567,89", 1234567.89, ",", ".", defaultBareNumber},
 		}
 		for _, d := range data {
 			t.Run(d.desc, func(t *testing.T) {
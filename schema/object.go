@@ -1,12 +1,50 @@
 package schema
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"fmt"
+)
 
-func castObject(value string) (interface{}, error) {
-	var obj interface{}
-	if err := json.Unmarshal([]byte(value), &obj); err != nil {
+// castObject casts a JSON object. Each property listed in props is cast using its
+// field descriptor, properties not listed are kept as decoded from JSON. The minLength
+// and maxLength constraints are checked against the number of properties.
+func castObject(value string, props map[string]Field, c Constraints) (interface{}, error) {
+	if len(props) == 0 && c.MinLength == 0 && c.MaxLength == 0 {
+		var obj interface{}
+		if err := json.Unmarshal([]byte(value), &obj); err != nil {
+			return nil, err
+		}
+		return obj, nil
+	}
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(value), &raw); err != nil || raw == nil {
+		return nil, fmt.Errorf("%s is not an JSON object", value)
+	}
+	if err := checkLengthConstraints(value, len(raw), c); err != nil {
 		return nil, err
 	}
+	obj := make(map[string]interface{}, len(raw))
+	for k, v := range raw {
+		p, ok := props[k]
+		if !ok {
+			var decoded interface{}
+			if err := json.Unmarshal(v, &decoded); err != nil {
+				return nil, err
+			}
+			obj[k] = decoded
+			continue
+		}
+		castd, err := castJSONValue(&p, v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid property %s: %v", k, err)
+		}
+		obj[k] = castd
+	}
+	for k, p := range props {
+		if _, ok := raw[k]; !ok && p.Constraints.Required {
+			return nil, fmt.Errorf("property %s is required", k)
+		}
+	}
 	return obj, nil
 }
 
@@ -1,7 +1,11 @@
 package schema
 
-import "testing"
-import "github.com/matryer/is"
+import (
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
 
 type eoStruct struct {
 	Name string `json:"name"`
@@ -24,3 +28,49 @@ func TestUncastObject(t *testing.T) {
 		})
 	}
 }
+
+func TestCastObject(t *testing.T) {
+	props := map[string]Field{
+		"id":   {Name: "id", Type: IntegerType, Constraints: Constraints{Required: true}},
+		"date": {Name: "date", Type: DateType},
+	}
+	t.Run("Success", func(t *testing.T) {
+		data := []struct {
+			desc  string
+			value string
+			props map[string]Field
+			want  interface{}
+		}{
+			{"NoProperties", `{"id":"1"}`, nil, map[string]interface{}{"id": "1"}},
+			{"Properties", `{"id":"1","date":"2020-01-02","other":true}`, props,
+				map[string]interface{}{"id": int64(1), "date": time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC), "other": true}},
+			{"OptionalMissing", `{"id":1}`, props, map[string]interface{}{"id": int64(1)}},
+		}
+		for _, d := range data {
+			t.Run(d.desc, func(t *testing.T) {
+				is := is.New(t)
+				got, err := castObject(d.value, d.props, Constraints{})
+				is.NoErr(err)
+				is.Equal(got, d.want)
+			})
+		}
+	})
+	t.Run("Error", func(t *testing.T) {
+		data := []struct {
+			desc  string
+			value string
+		}{
+			{"NotAnObject", `[1]`},
+			{"RequiredMissing", `{"date":"2020-01-02"}`},
+			{"RequiredNull", `{"id":null}`},
+			{"InvalidProperty", `{"id":1,"date":"foo"}`},
+		}
+		for _, d := range data {
+			t.Run(d.desc, func(t *testing.T) {
+				is := is.New(t)
+				_, err := castObject(d.value, props, Constraints{})
+				is.True(err != nil)
+			})
+		}
+	})
+}
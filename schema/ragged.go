@@ -0,0 +1,228 @@
+package schema
+
+import (
+	"fmt"
+
+	"github.com/frictionlessdata/tableschema-go/table"
+)
+
+// extraCellsTag is the tableheader tag value of the struct field ([]string) that
+// receives the extra cells collected by CastRow (see CollectExtraCells).
+const extraCellsTag = "*"
+
+// maxRaggedRows is the maximum number of ragged rows kept by InferReport.
+const maxRaggedRows = 100
+
+// RaggedRowAction defines what is done with a ragged row, that is, a row which does
+// not have one cell per field (or header).
+type RaggedRowAction int
+
+const (
+	// FailOnRaggedRow makes the processing of the row fail. This is the default.
+	FailOnRaggedRow RaggedRowAction = iota
+	// SkipRaggedRow ignores the row.
+	SkipRaggedRow
+	// PadRaggedRow fills the missing cells of short rows with missing values.
+	PadRaggedRow
+	// TruncateRaggedRow drops the extra cells of long rows.
+	TruncateRaggedRow
+	// CollectExtraCells drops the extra cells of long rows from the row, but keeps
+	// them aside: they are listed by InferReport and set by CastRow in the struct
+	// field tagged `tableheader:"*"`, which must be a []string.
+	CollectExtraCells
+)
+
+func (a RaggedRowAction) String() string {
+	switch a {
+	case FailOnRaggedRow:
+		return "fail"
+	case SkipRaggedRow:
+		return "skip"
+	case PadRaggedRow:
+		return "pad"
+	case TruncateRaggedRow:
+		return "truncate"
+	case CollectExtraCells:
+		return "collect"
+	}
+	return fmt.Sprintf("RaggedRowAction(%d)", int(a))
+}
+
+// RaggedRows is the policy applied to ragged rows. The zero value fails on all
+// ragged rows.
+type RaggedRows struct {
+	// Short is applied to rows with fewer cells than fields: FailOnRaggedRow,
+	// SkipRaggedRow or PadRaggedRow.
+	Short RaggedRowAction
+	// Long is applied to rows with more cells than fields: FailOnRaggedRow,
+	// SkipRaggedRow, TruncateRaggedRow or CollectExtraCells.
+	Long RaggedRowAction
+}
+
+func (p RaggedRows) validate() error {
+	switch p.Short {
+	case FailOnRaggedRow, SkipRaggedRow, PadRaggedRow:
+	default:
+		return fmt.Errorf("invalid action for short rows:%v", p.Short)
+	}
+	switch p.Long {
+	case FailOnRaggedRow, SkipRaggedRow, TruncateRaggedRow, CollectExtraCells:
+	default:
+		return fmt.Errorf("invalid action for long rows:%v", p.Long)
+	}
+	return nil
+}
+
+// action returns the action applied to a row of the given number of cells, when n
+// cells are expected. Invalid actions fail.
+func (p RaggedRows) action(cells, n int) RaggedRowAction {
+	a := FailOnRaggedRow
+	switch {
+	case cells < n && (p.Short == SkipRaggedRow || p.Short == PadRaggedRow):
+		a = p.Short
+	case cells > n && (p.Long == SkipRaggedRow || p.Long == TruncateRaggedRow || p.Long == CollectExtraCells):
+		a = p.Long
+	}
+	return a
+}
+
+// normalize applies the policy to a ragged row, which is returned with n cells
+// along with the collected extra cells. Missing cells are filled by pad. The row is
+// returned unchanged when it must be skipped or fail.
+func (p RaggedRows) normalize(row []string, n int, pad func(i int) string) ([]string, []string, RaggedRowAction) {
+	a := p.action(len(row), n)
+	switch a {
+	case PadRaggedRow:
+		ret := make([]string, n)
+		copy(ret, row)
+		for i := len(row); i < n; i++ {
+			ret[i] = pad(i)
+		}
+		return ret, nil, a
+	case TruncateRaggedRow:
+		return row[:n], nil, a
+	case CollectExtraCells:
+		return row[:n], row[n:], a
+	}
+	return row, nil, a
+}
+
+// RaggedRowError is returned when a ragged row fails or is skipped.
+type RaggedRowError struct {
+	// Cells is the number of cells of the row.
+	Cells int
+	// Fields is the number of fields of the schema.
+	Fields int
+	// Skipped is true if the row is skipped by the policy. CastTable and Migrate
+	// drop skipped rows without reporting them.
+	Skipped bool
+}
+
+func (e *RaggedRowError) Error() string {
+	if e.Skipped {
+		return fmt.Sprintf("the row with %d values was skipped, as it does not match the %d fields in the schema", e.Cells, e.Fields)
+	}
+	return fmt.Sprintf("the row with %d values does not match the %d fields in the schema", e.Cells, e.Fields)
+}
+
+// normalizeRow applies the RaggedRows policy of the schema to the row. Missing cells
+// are filled with the first missing value of their fields.
+func (s *Schema) normalizeRow(row []string) ([]string, []string, error) {
+	if len(row) == len(s.Fields) {
+		return row, nil, nil
+	}
+	ret, extra, a := s.RaggedRows.normalize(row, len(s.Fields), func(i int) string {
+		return s.Fields[i].firstMissingValue(s)
+	})
+	switch a {
+	case FailOnRaggedRow, SkipRaggedRow:
+		return nil, nil, &RaggedRowError{Cells: len(row), Fields: len(s.Fields), Skipped: a == SkipRaggedRow}
+	}
+	return ret, extra, nil
+}
+
+// RaggedRow describes a ragged row found by inference.
+type RaggedRow struct {
+	// Row is the index of the row in the table, starting at 0.
+	Row int
+	// Cells is the number of cells of the row.
+	Cells int
+	// Action is the action applied to the row.
+	Action RaggedRowAction
+	// Extra holds the extra cells collected by CollectExtraCells.
+	Extra []string
+}
+
+// WithRaggedRows sets the policy applied to ragged rows, which do not have one cell
+// per header. By default, inference fails on the first ragged row. Padded cells are
+// filled with the first missing value (see WithMissingValues) and skipped rows do not
+// count towards the SampleLimit. The policy is recorded in the inferred schema, so
+// CastRow and CastTable handle the ragged rows of the table in the same way, and in the
+// InferReport, along with the ragged rows found.
+func WithRaggedRows(p RaggedRows) InferOpts {
+	return func(c *inferConfig) error {
+		if err := p.validate(); err != nil {
+			return err
+		}
+		c.ragged = p
+		return nil
+	}
+}
+
+// iter returns an iterator over the rows of the table in which ragged rows are
+// handled as defined by WithRaggedRows. Rows that must fail are kept as they are.
+func (c *inferConfig) iter(tab table.Table) (table.Iterator, error) {
+	iter, err := tab.Iter()
+	if err != nil {
+		return nil, err
+	}
+	if c.report != nil {
+		// The table might be read more than once, as by stratified sampling.
+		c.report.RaggedRows, c.report.Ragged, c.report.RaggedCount = c.ragged, nil, 0
+	}
+	pad := defaultMissingValue
+	if len(c.missingValues) > 0 {
+		pad = c.missingValues[0]
+	}
+	return &raggedIterator{
+		Iterator: iter,
+		n:        len(tab.Headers()),
+		cfg:      c,
+		pad:      func(int) string { return pad },
+		index:    -1,
+	}, nil
+}
+
+type raggedIterator struct {
+	table.Iterator
+	n     int
+	cfg   *inferConfig
+	pad   func(int) string
+	index int
+	row   []string
+}
+
+func (i *raggedIterator) Next() bool {
+	for i.Iterator.Next() {
+		i.index++
+		row := i.Iterator.Row()
+		if len(row) == i.n {
+			i.row = row
+			return true
+		}
+		ret, extra, a := i.cfg.ragged.normalize(row, i.n, i.pad)
+		if r := i.cfg.report; r != nil {
+			r.RaggedCount++
+			if len(r.Ragged) < maxRaggedRows {
+				r.Ragged = append(r.Ragged, RaggedRow{Row: i.index, Cells: len(row), Action: a, Extra: extra})
+			}
+		}
+		if a != SkipRaggedRow {
+			i.row = ret
+			return true
+		}
+	}
+	return false
+}
+
+func (i *raggedIterator) Row() []string { return i.row }
@@ -0,0 +1,159 @@
+package schema
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/matryer/is"
+
+	"github.com/frictionlessdata/tableschema-go/table"
+)
+
+func TestInfer_RaggedRows(t *testing.T) {
+	tab := table.FromSlices(
+		[]string{"Name", "Age"},
+		[][]string{
+			{"Foo", "10"},
+			{"Bar"},
+			{"Bez", "8", "extra"},
+			{"Boo", "21"},
+		})
+	t.Run("Fail", func(t *testing.T) {
+		is := is.New(t)
+		_, err := Infer(tab)
+		is.True(err != nil)
+		_, err = InferImplicitCasting(tab, WithStreaming())
+		is.True(err != nil)
+	})
+	t.Run("PadAndCollect", func(t *testing.T) {
+		is := is.New(t)
+		var r InferReport
+		p := RaggedRows{Short: PadRaggedRow, Long: CollectExtraCells}
+		s, err := Infer(tab, WithRaggedRows(p), WithMissingValues("NA"), WithInferReport(&r))
+		is.NoErr(err)
+		is.Equal(s.Fields[1].Type, IntegerType)
+		is.True(!s.Fields[1].Constraints.Required) // padded with NA
+		is.Equal(s.RaggedRows, p)
+		is.Equal(r.RaggedRows, p)
+		is.Equal(r.RaggedCount, 2)
+		want := []RaggedRow{
+			{Row: 1, Cells: 1, Action: PadRaggedRow},
+			{Row: 2, Cells: 3, Action: CollectExtraCells, Extra: []string{"extra"}},
+		}
+		if !reflect.DeepEqual(r.Ragged, want) {
+			t.Fatalf("got:%+v want:%+v", r.Ragged, want)
+		}
+	})
+	t.Run("Skip", func(t *testing.T) {
+		is := is.New(t)
+		var r InferReport
+		s, err := InferImplicitCasting(tab,
+			WithRaggedRows(RaggedRows{Short: SkipRaggedRow, Long: SkipRaggedRow}),
+			WithMissingValues(""),
+			WithInferReport(&r),
+			WithStreaming())
+		is.NoErr(err)
+		is.True(s.Fields[1].Constraints.Required)
+		is.Equal(r.RaggedCount, 2)
+		is.Equal(r.Ragged[0].Action, SkipRaggedRow)
+	})
+	t.Run("SkippedRowsDoNotCountTowardsLimit", func(t *testing.T) {
+		is := is.New(t)
+		s, err := Infer(tab,
+			WithRaggedRows(RaggedRows{Short: SkipRaggedRow, Long: SkipRaggedRow}),
+			SampleLimit(2),
+			WithConstraintInference(ConstraintInference{Bounds: true}))
+		is.NoErr(err)
+		// Foo and Boo were sampled.
+		is.Equal(s.Fields[1].Constraints.Minimum, "10")
+		is.Equal(s.Fields[1].Constraints.Maximum, "21")
+	})
+	t.Run("InvalidPolicy", func(t *testing.T) {
+		is := is.New(t)
+		_, err := Infer(tab, WithRaggedRows(RaggedRows{Short: TruncateRaggedRow}))
+		is.True(err != nil)
+		_, err = Infer(tab, WithRaggedRows(RaggedRows{Long: PadRaggedRow}))
+		is.True(err != nil)
+	})
+}
+
+func TestCastRow_RaggedRows(t *testing.T) {
+	type person struct {
+		Name  string
+		Age   *int
+		Extra []string `tableheader:"*"`
+	}
+	s := Schema{
+		Fields:     []Field{{Name: "Name", Type: StringType}, {Name: "Age", Type: IntegerType}},
+		RaggedRows: RaggedRows{Short: PadRaggedRow, Long: CollectExtraCells},
+	}
+	t.Run("Pad", func(t *testing.T) {
+		is := is.New(t)
+		var p person
+		is.NoErr(s.CastRow([]string{"Foo"}, &p))
+		is.Equal(p.Name, "Foo")
+		is.True(p.Age == nil)
+		is.True(p.Extra == nil)
+	})
+	t.Run("Collect", func(t *testing.T) {
+		is := is.New(t)
+		var p person
+		is.NoErr(s.CastRow([]string{"Foo", "10", "a", "b"}, &p))
+		is.Equal(*p.Age, 10)
+		is.Equal(p.Extra, []string{"a", "b"})
+	})
+	t.Run("Fail", func(t *testing.T) {
+		is := is.New(t)
+		s := Schema{Fields: s.Fields}
+		var p person
+		err := s.CastRow([]string{"Foo"}, &p)
+		var rerr *RaggedRowError
+		is.True(errors.As(err, &rerr))
+		is.Equal(*rerr, RaggedRowError{Cells: 1, Fields: 2})
+	})
+	t.Run("PadRequiredField", func(t *testing.T) {
+		is := is.New(t)
+		s := s
+		s.Fields = []Field{{Name: "Name", Type: StringType}, {Name: "Age", Type: IntegerType, Constraints: Constraints{Required: true}}}
+		var p person
+		var rerr *RequiredError
+		is.True(errors.As(s.CastRow([]string{"Foo"}, &p), &rerr))
+	})
+}
+
+func TestCastTable_RaggedRows(t *testing.T) {
+	type person struct {
+		Name string
+		Age  int
+	}
+	tab := table.FromSlices(
+		[]string{"Name", "Age"},
+		[][]string{{"Foo", "10"}, {"Bar"}, {"Bez", "8", "extra"}})
+	s := Schema{
+		Fields:     []Field{{Name: "Name", Type: StringType}, {Name: "Age", Type: IntegerType}},
+		RaggedRows: RaggedRows{Short: SkipRaggedRow, Long: FailOnRaggedRow},
+	}
+	is := is.New(t)
+	var got []person
+	err := s.CastTable(tab, &got)
+	var cv *ConversionError
+	is.True(errors.As(err, &cv))
+	is.Equal(len(cv.Errors), 1)
+	is.Equal(cv.Errors[0].LineNumber, 2)
+	is.Equal(got, []person{{"Foo", 10}})
+}
+
+func TestMigrate_RaggedRows(t *testing.T) {
+	is := is.New(t)
+	from := &Schema{
+		Fields:     []Field{{Name: "Name", Type: StringType}, {Name: "Age", Type: IntegerType}},
+		RaggedRows: RaggedRows{Short: SkipRaggedRow, Long: TruncateRaggedRow},
+	}
+	to := &Schema{Fields: []Field{{Name: "Age", Type: NumberType}}}
+	m, err := Migrate(table.FromSlices([]string{"Name", "Age"}, [][]string{{"Foo", "10"}, {"Bar"}, {"Bez", "8", "extra"}}), from, to, MigrationRules{})
+	is.NoErr(err)
+	rows, err := m.ReadAll()
+	is.NoErr(err)
+	is.Equal(rows, [][]string{{"10"}, {"8"}})
+}
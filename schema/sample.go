@@ -0,0 +1,181 @@
+package schema
+
+import (
+	"math/rand"
+	"sort"
+
+	"github.com/frictionlessdata/tableschema-go/table"
+)
+
+// sampling defines which rows are sampled for inference.
+type sampling int
+
+const (
+	// sampleHead samples the first rows of the table.
+	sampleHead sampling = iota
+	// sampleReservoir samples rows uniformly across the table, in a single pass.
+	sampleReservoir
+	// sampleStratified samples one row of each of equally sized slices of the table.
+	sampleStratified
+)
+
+// WithReservoirSampling makes inference sample rows uniformly across the whole table,
+// instead of taking its first rows. The table is read once and only the sampled rows
+// (see SampleLimit) are kept in memory. Sampled rows are chosen randomly, see
+// WithSamplingSeed.
+func WithReservoirSampling() InferOpts {
+	return func(c *inferConfig) error {
+		c.sampling = sampleReservoir
+		return nil
+	}
+}
+
+// WithStratifiedSampling makes inference split the table into as many equally sized
+// slices as rows to sample (see SampleLimit) and sample a random row of each slice.
+// It guarantees all parts of the table are represented, for instance, all periods
+// of a table sorted by date. The table is read twice: the first time to count its
+// rows. Sampled rows are chosen randomly, see WithSamplingSeed.
+func WithStratifiedSampling() InferOpts {
+	return func(c *inferConfig) error {
+		c.sampling = sampleStratified
+		return nil
+	}
+}
+
+// WithSamplingSeed sets the seed used to randomly choose the sampled rows. Inference
+// is deterministic: the same seed always samples the same rows of a table. The
+// default seed is 0.
+func WithSamplingSeed(seed int64) InferOpts {
+	return func(c *inferConfig) error {
+		c.seed = seed
+		return nil
+	}
+}
+
+// WithStreaming makes inference update the per-column type counters row by row,
+// instead of sampling the rows first, so memory usage does not depend on the number
+// of rows. Combined with SampleLimit(SampleAllRows), it infers the types of huge
+// tables from all their rows.
+//
+// As rows are not kept, streaming can not be combined with format, categorical and
+// constraint inference, nor with reservoir and stratified sampling.
+func WithStreaming() InferOpts {
+	return func(c *inferConfig) error {
+		c.streaming = true
+		return nil
+	}
+}
+
+// limit returns the maximum number of rows to sample. Non-positive values mean all rows.
+func (c *inferConfig) limit() int {
+	if c.sampleLimit != 0 {
+		return c.sampleLimit
+	}
+	return defaultMaxNumRowsInfer
+}
+
+func sample(tab table.Table, cfg *inferConfig) ([][]string, error) {
+	limit := cfg.limit()
+	if limit > 0 {
+		switch cfg.sampling {
+		case sampleReservoir:
+			return sampleRandom(tab, cfg, limit, rand.New(rand.NewSource(cfg.seed)))
+		case sampleStratified:
+			return sampleStrata(tab, cfg, limit, rand.New(rand.NewSource(cfg.seed)))
+		}
+	}
+	iter, err := cfg.iter(tab)
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+	var t [][]string
+	for count := 0; iter.Next(); count++ {
+		t = append(t, iter.Row())
+		// A negative limit will continue to sample the entire table.
+		if limit > 0 && count == limit-1 {
+			break
+		}
+	}
+	if iter.Err() != nil {
+		return nil, iter.Err()
+	}
+	return t, nil
+}
+
+// sampleRandom samples limit rows uniformly, using reservoir sampling. Sampled rows
+// keep their relative order.
+func sampleRandom(tab table.Table, cfg *inferConfig, limit int, rnd *rand.Rand) ([][]string, error) {
+	iter, err := cfg.iter(tab)
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+	type sampledRow struct {
+		index int
+		row   []string
+	}
+	var reservoir []sampledRow
+	for i := 0; iter.Next(); i++ {
+		if i < limit {
+			reservoir = append(reservoir, sampledRow{i, iter.Row()})
+			continue
+		}
+		if j := rnd.Intn(i + 1); j < limit {
+			reservoir[j] = sampledRow{i, iter.Row()}
+		}
+	}
+	if iter.Err() != nil {
+		return nil, iter.Err()
+	}
+	// Restoring the table order.
+	sort.Slice(reservoir, func(i, j int) bool { return reservoir[i].index < reservoir[j].index })
+	t := make([][]string, len(reservoir))
+	for i, r := range reservoir {
+		t[i] = r.row
+	}
+	return t, nil
+}
+
+// sampleStrata samples a random row of each of limit equally sized slices of the table.
+func sampleStrata(tab table.Table, cfg *inferConfig, limit int, rnd *rand.Rand) ([][]string, error) {
+	n, err := countRows(tab, cfg)
+	if err != nil {
+		return nil, err
+	}
+	selected := make(map[int]struct{}, limit)
+	if n > limit {
+		for i := 0; i < limit; i++ {
+			begin, end := i*n/limit, (i+1)*n/limit
+			selected[begin+rnd.Intn(end-begin)] = struct{}{}
+		}
+	}
+	iter, err := cfg.iter(tab)
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+	var t [][]string
+	for i := 0; iter.Next(); i++ {
+		if _, ok := selected[i]; ok || n <= limit {
+			t = append(t, iter.Row())
+		}
+	}
+	if iter.Err() != nil {
+		return nil, iter.Err()
+	}
+	return t, nil
+}
+
+func countRows(tab table.Table, cfg *inferConfig) (int, error) {
+	iter, err := cfg.iter(tab)
+	if err != nil {
+		return 0, err
+	}
+	defer iter.Close()
+	n := 0
+	for iter.Next() {
+		n++
+	}
+	return n, iter.Err()
+}
@@ -0,0 +1,133 @@
+package schema
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/matryer/is"
+
+	"github.com/frictionlessdata/tableschema-go/table"
+)
+
+// sortedTable returns a table whose first rows are integers and the last ones dates.
+func sortedTable(n int) table.Table {
+	rows := make([][]string, n)
+	for i := range rows {
+		rows[i] = []string{strconv.Itoa(i)}
+		if i >= n/2 {
+			rows[i] = []string{"2015-01-02"}
+		}
+	}
+	return table.FromSlices([]string{"f"}, rows)
+}
+
+func TestSample(t *testing.T) {
+	t.Run("Reservoir", func(t *testing.T) {
+		is := is.New(t)
+		cfg, err := newInferConfig([]InferOpts{SampleLimit(10), WithReservoirSampling()})
+		is.NoErr(err)
+		s, err := sample(sortedTable(1000), cfg)
+		is.NoErr(err)
+		is.Equal(len(s), 10)
+		// Rows keep the table order.
+		dates := 0
+		for i, row := range s {
+			if row[0] == "2015-01-02" {
+				dates++
+				continue
+			}
+			is.True(dates == 0) // integers come first
+			if i > 0 {
+				prev, _ := strconv.Atoi(s[i-1][0])
+				curr, _ := strconv.Atoi(row[0])
+				is.True(prev < curr)
+			}
+		}
+		is.True(dates > 0 && dates < 10)
+		// Same seed, same sample.
+		s2, err := sample(sortedTable(1000), cfg)
+		is.NoErr(err)
+		is.Equal(s, s2)
+	})
+	t.Run("Stratified", func(t *testing.T) {
+		is := is.New(t)
+		cfg, err := newInferConfig([]InferOpts{SampleLimit(10), WithStratifiedSampling(), WithSamplingSeed(42)})
+		is.NoErr(err)
+		s, err := sample(sortedTable(100), cfg)
+		is.NoErr(err)
+		is.Equal(len(s), 10)
+		for i := 0; i < 5; i++ {
+			n, err := strconv.Atoi(s[i][0])
+			is.NoErr(err)
+			is.True(n >= i*10 && n < (i+1)*10) // one row per stratum
+		}
+		for i := 5; i < 10; i++ {
+			is.Equal(s[i][0], "2015-01-02")
+		}
+	})
+	t.Run("SmallTable", func(t *testing.T) {
+		is := is.New(t)
+		for _, opt := range []InferOpts{WithReservoirSampling(), WithStratifiedSampling()} {
+			cfg, err := newInferConfig([]InferOpts{SampleLimit(10), opt})
+			is.NoErr(err)
+			s, err := sample(sortedTable(4), cfg)
+			is.NoErr(err)
+			is.Equal(s, [][]string{{"0"}, {"1"}, {"2015-01-02"}, {"2015-01-02"}})
+		}
+	})
+	t.Run("Infer", func(t *testing.T) {
+		is := is.New(t)
+		s, err := Infer(sortedTable(1000))
+		is.NoErr(err)
+		is.Equal(s.Fields[0].Type, IntegerType) // only the head was sampled
+		s, err = InferImplicitCasting(sortedTable(1000), WithReservoirSampling())
+		is.NoErr(err)
+		is.Equal(s.Fields[0].Type, StringType)
+		s, err = InferImplicitCasting(sortedTable(1000), WithStratifiedSampling())
+		is.NoErr(err)
+		is.Equal(s.Fields[0].Type, StringType)
+	})
+}
+
+func TestInferStreaming(t *testing.T) {
+	t.Run("AllRows", func(t *testing.T) {
+		is := is.New(t)
+		var r InferReport
+		s, err := Infer(sortedTable(1000), WithStreaming(), SampleLimit(SampleAllRows), WithInferReport(&r))
+		is.NoErr(err)
+		is.Equal(s.Fields[0].Type, DateType) // 500 dates vs. 498 integers and 2 booleans.
+		is.Equal(r.Columns[0].Types[DateType], 500)
+	})
+	t.Run("ImplicitCasting", func(t *testing.T) {
+		is := is.New(t)
+		s, err := InferImplicitCasting(sortedTable(1000), WithStreaming(), SampleLimit(SampleAllRows))
+		is.NoErr(err)
+		is.Equal(s.Fields[0].Type, StringType)
+	})
+	t.Run("SampleLimit", func(t *testing.T) {
+		is := is.New(t)
+		s, err := Infer(sortedTable(1000), WithStreaming())
+		is.NoErr(err)
+		is.Equal(s.Fields[0].Type, IntegerType)
+	})
+	t.Run("MissingValues", func(t *testing.T) {
+		is := is.New(t)
+		s, err := Infer(table.FromSlices([]string{"a", "b"}, [][]string{{"10", "x"}, {"NA", "y"}}), WithStreaming(), WithMissingValues("NA"))
+		is.NoErr(err)
+		is.Equal(s.Fields[0].Type, IntegerType)
+		is.True(!s.Fields[0].Constraints.Required)
+		is.True(s.Fields[1].Constraints.Required)
+	})
+	t.Run("NotTabular", func(t *testing.T) {
+		is := is.New(t)
+		_, err := Infer(table.FromSlices([]string{"a", "b"}, [][]string{{"1"}}), WithStreaming())
+		is.True(err != nil)
+	})
+	t.Run("InvalidOptions", func(t *testing.T) {
+		is := is.New(t)
+		for _, opt := range []InferOpts{WithFormatInference(), WithCategoricalInference(2), WithConstraintInference(DefaultConstraintInference), WithReservoirSampling(), WithStratifiedSampling()} {
+			_, err := Infer(sortedTable(10), WithStreaming(), opt)
+			is.True(err != nil)
+		}
+	})
+}
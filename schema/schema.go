@@ -2,7 +2,9 @@ package schema
 
 import (
 	"bytes"
+	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -42,22 +44,37 @@ func Read(r io.Reader) (*Schema, error) {
 	if err := dec.Decode(&s); err != nil {
 		return nil, err
 	}
-	if len(s.MissingValues) == 0 {
-		return &s, nil
+	s.propagateMissingValues()
+	return &s, nil
+}
+
+// propagateMissingValues copies the schema-level missing values to the fields
+// which do not declare their own.
+func (s *Schema) propagateMissingValues() {
+	if s.MissingValues == nil {
+		return
 	}
 	// Transforming the list in a set.
 	valueSet := make(map[string]struct{}, len(s.MissingValues))
 	for _, v := range s.MissingValues {
 		valueSet[v] = struct{}{}
 	}
-	// Updating fields.
+	// Updating fields which do not declare their own missing values.
 	for i := range s.Fields {
+		if s.Fields[i].MissingValues != nil {
+			continue
+		}
 		s.Fields[i].MissingValues = make(map[string]struct{}, len(valueSet))
 		for k, v := range valueSet {
 			s.Fields[i].MissingValues[k] = v
 		}
+		if len(s.MissingValueLabels) > 0 {
+			s.Fields[i].MissingValueLabels = make(map[string]string, len(s.MissingValueLabels))
+			for k, v := range s.MissingValueLabels {
+				s.Fields[i].MissingValueLabels[k] = v
+			}
+		}
 	}
-	return &s, nil
 }
 
 // LoadFromFile loads and parses a schema descriptor from a local file.
@@ -118,7 +135,17 @@ type Schema struct {
 	PrimaryKeyPlaceholder interface{}   `json:"primaryKey,omitempty"`
 	PrimaryKeys           []string      `json:"-"`
 	ForeignKeys           []ForeignKeys `json:"foreignKeys,omitempty"`
-	MissingValues         []string      `json:"missingValues,omitempty"`
+
+	MissingValuesPlaceholder interface{} `json:"missingValues,omitempty"`
+	// MissingValues lists the string values that represent null values. It applies to
+	// all fields that do not declare their own missing values. If nil, [""] is used.
+	MissingValues []string `json:"-"`
+	// MissingValueLabels maps missing values to the label describing why the value
+	// is missing (Table Schema v2).
+	MissingValueLabels map[string]string `json:"-"`
+	// RaggedRows is the policy applied to rows which do not have one cell per field.
+	// It is not part of the descriptor.
+	RaggedRows RaggedRows `json:"-"`
 }
 
 // GetField fetches the index and field referenced by the name argument.
@@ -137,35 +164,14 @@ func (s *Schema) HasField(name string) bool {
 	return pos != InvalidPosition
 }
 
-// Validate checks whether the schema is valid. If it is not, returns an error
-// describing the problem.
+// Validate checks whether the schema is valid. If it is not, returns a
+// *ValidationError listing all problems found, each one along with the JSON
+// pointer to the offending property.
 // More at: https://specs.frictionlessdata.io/table-schema/
 func (s *Schema) Validate() error {
-	// Checking if all fields have a name.
-	for _, f := range s.Fields {
-		if f.Name == "" {
-			return fmt.Errorf("invalid field: attribute name is mandatory")
-		}
-	}
-	// Checking primary keys.
-	for _, pk := range s.PrimaryKeys {
-		if !s.HasField(pk) {
-			return fmt.Errorf("invalid primary key: there is no field %s", pk)
-		}
-	}
-	// Checking foreign keys.
-	for _, fk := range s.ForeignKeys {
-		for _, f := range fk.Fields {
-			if !s.HasField(f) {
-				return fmt.Errorf("invalid foreign keys: there is no field %s", fk)
-			}
-		}
-		if len(fk.Reference.Fields) != len(fk.Fields) {
-			return fmt.Errorf("invalid foreign key: foreignKey.fields must contain the same number entries as foreignKey.reference.fields")
-		}
-	}
-
-	return nil
+	var v validator
+	v.schema(s)
+	return v.err()
 }
 
 // Write writes the schema descriptor.
@@ -191,6 +197,14 @@ func (s *Schema) SaveToFile(path string) error {
 // by out. The out value must be pointer to a struct. Only exported fields will be unmarshalled.
 // The lowercased field name is used as the key for each exported field.
 //
+// Missing cells leave pointer fields nil, invalidate Null fields (keeping the missing value
+// label) and are scanned as nil by sql.Scanner fields (e.g. sql.NullInt64). Other fields are
+// left untouched. Missing cells of required fields, which include the primary key fields,
+// make this call return a *RequiredError.
+//
+// Rows which do not have one cell per field are handled as defined by the RaggedRows policy
+// of the schema. Rows that fail or must be skipped make this call return a *RaggedRowError.
+//
 // If a value in the row cannot be marshalled to its respective schema field (Field.Unmarshal),
 // this call will return an error. Furthermore, this call is also going to return an error if
 // the schema field value can not be unmarshalled to the struct field type.
@@ -198,8 +212,15 @@ func (s *Schema) CastRow(row []string, out interface{}) error {
 	if reflect.ValueOf(out).Kind() != reflect.Ptr || reflect.Indirect(reflect.ValueOf(out)).Kind() != reflect.Struct {
 		return fmt.Errorf("can only cast pointer to structs")
 	}
-	if len(row) != len(s.Fields) {
-		return fmt.Errorf("the row with %d values does not match the %d fields in the schema", len(row), len(s.Fields))
+	row, extra, err := s.normalizeRow(row)
+	if err != nil {
+		return err
+	}
+	// Checking required fields first, as they might not be mapped to the struct.
+	for i := range s.Fields {
+		if _, ok := s.Fields[i].missingValueLabel(row[i], s); ok && s.isRequired(&s.Fields[i]) {
+			return &RequiredError{Field: s.Fields[i].Name, Value: row[i]}
+		}
 	}
 	fields, err := getStructFields(out)
 	if err != nil {
@@ -210,10 +231,19 @@ func (s *Schema) CastRow(row []string, out interface{}) error {
 		if !ok { // if no tag is set use own name
 			fieldName = f.StructField.Name
 		}
+		if fieldName == extraCellsTag {
+			if err := f.Set(extra); err != nil {
+				return err
+			}
+			continue
+		}
 		schemaField, fieldIndex := s.GetField(fieldName)
 		if fieldIndex != InvalidPosition {
 			cell := row[fieldIndex]
-			if s.isMissingValue(cell) {
+			if label, ok := schemaField.missingValueLabel(cell, s); ok {
+				if err := f.setNull(label); err != nil {
+					return err
+				}
 				continue
 			}
 			v, err := schemaField.Cast(cell)
@@ -237,6 +267,20 @@ func (s *structField) Set(rowValue interface{}) error {
 	toSetValue := reflect.ValueOf(rowValue)
 	toSetType := toSetValue.Type()
 	switch {
+	case s.Type == nullType:
+		s.value.Set(reflect.ValueOf(Null{Value: rowValue, Valid: true}))
+	case isScanner(s.Type):
+		if err := s.value.Addr().Interface().(sql.Scanner).Scan(rowValue); err != nil {
+			return fmt.Errorf("field:%s value:%v - %v", s.Name, rowValue, err)
+		}
+	case s.Type.Kind() == reflect.Ptr && isNullable(s.Type.Elem()):
+		v := reflect.New(s.Type.Elem())
+		elem := structField{s.StructField, v.Elem()}
+		elem.Type = s.Type.Elem()
+		if err := elem.Set(rowValue); err != nil {
+			return err
+		}
+		s.value.Set(v)
 	case s.Type.ConvertibleTo(reflect.PtrTo(toSetType)):
 		v := reflect.New(toSetType)
 		vType := v.Elem().Type()
@@ -244,6 +288,10 @@ func (s *structField) Set(rowValue interface{}) error {
 		s.value.Set(v)
 	case toSetType.ConvertibleTo(s.Type):
 		s.value.Set(toSetValue.Convert(s.Type))
+	case s.Type.Kind() == reflect.Ptr && toSetType.ConvertibleTo(s.Type.Elem()):
+		v := reflect.New(s.Type.Elem())
+		v.Elem().Set(toSetValue.Convert(s.Type.Elem()))
+		s.value.Set(v)
 	default:
 		return fmt.Errorf("field:%s value:%v - cannot convert from %v to %v", s.Name, rowValue, toSetType, s.Type)
 	}
@@ -261,9 +309,13 @@ func getStructFields(out interface{}) ([]structField, error) {
 		fieldValue := outv.Field(i)
 		if fieldValue.CanSet() { // Only consider exported fields.
 			switch {
-			// Special case on datetime fields, wich is a first-class schema
-			// type, represented as a struct with all fields unexported.
-			case fieldValue.Type() == reflect.TypeOf(time.Time{}):
+			// Special case on datetime and geopoint fields, which are first-class
+			// schema types represented as structs.
+			case fieldValue.Type() == timeType || fieldValue.Type() == geoPointType:
+				fields = append(fields, structField{outt.Field(i), fieldValue})
+
+			// Nullable types (like Null and sql.NullString) are also set as a whole.
+			case isNullable(fieldValue.Type()):
 				fields = append(fields, structField{outt.Field(i), fieldValue})
 
 			// It it is a struct, deep dive on fields recursively.
@@ -276,15 +328,17 @@ func getStructFields(out interface{}) ([]structField, error) {
 
 			// If it is a pointer.
 			case fieldValue.Kind() == reflect.Ptr:
-				// Allocate memory to it.
-				fieldValue.Set(reflect.New(fieldValue.Type().Elem()))
-
-				// If it is not a struct, simply add to the list.
-				if fieldValue.Type().Elem().Kind() != reflect.Struct {
+				// If it does not point to a struct, simply add to the list. Memory
+				// is allocated when the value is set, so missing cells are kept nil.
+				elemType := fieldValue.Type().Elem()
+				if elemType.Kind() != reflect.Struct || elemType == timeType || elemType == geoPointType || isNullable(elemType) {
 					fields = append(fields, structField{outt.Field(i), fieldValue})
 					break
 				}
 
+				// Allocate memory to it.
+				fieldValue.Set(reflect.New(elemType))
+
 				// It it is a struct, deep dive on fields recursively.
 				newF, err := getStructFields(reflect.Indirect(fieldValue).Addr().Interface())
 				if err != nil {
@@ -313,6 +367,8 @@ func (r rawRow) Less(i, j int) bool { return r[i].pos < r[j].pos }
 
 // UncastRow uncasts struct into a row. This method can only uncast structs (or pointer to structs) and
 // will error out if nil is passed.
+// Null values (nil pointers, invalid Null and sql.Null* values) are uncast to the first missing
+// value of the field.
 // The order of the cells in the returned row is the schema declaration order.
 func (s *Schema) UncastRow(in interface{}) ([]string, error) {
 	inValue := reflect.Indirect(reflect.ValueOf(in))
@@ -329,7 +385,19 @@ func (s *Schema) UncastRow(in interface{}) ([]string, error) {
 		}
 		f, fieldIndex := s.GetField(fieldName)
 		if fieldIndex != InvalidPosition {
-			cell, err := f.Uncast(structFieldValue.Interface())
+			v, valid, err := nullableValue(structFieldValue)
+			if err != nil {
+				return nil, err
+			}
+			if !valid {
+				mv := f.firstMissingValue(s)
+				if s.isRequired(f) {
+					return nil, &RequiredError{Field: f.Name, Value: mv}
+				}
+				row = append(row, rawCell{fieldIndex, mv})
+				continue
+			}
+			cell, err := f.Uncast(v)
 			if err != nil {
 				return nil, err
 			}
@@ -344,15 +412,6 @@ func (s *Schema) UncastRow(in interface{}) ([]string, error) {
 	return ret, nil
 }
 
-func (s *Schema) isMissingValue(value string) bool {
-	for _, mv := range s.MissingValues {
-		if mv == value {
-			return true
-		}
-	}
-	return false
-}
-
 // UnmarshalJSON sets *f to a copy of data. It will respect the default values
 // described at: https://specs.frictionlessdata.io/table-schema/
 func (s *Schema) UnmarshalJSON(data []byte) error {
@@ -366,6 +425,12 @@ func (s *Schema) UnmarshalJSON(data []byte) error {
 		return fmt.Errorf("primaryKey must be either a string or list")
 	}
 	a.PrimaryKeyPlaceholder = nil
+	mvs, labels, err := parseMissingValues(a.MissingValuesPlaceholder)
+	if err != nil {
+		return err
+	}
+	a.MissingValues, a.MissingValueLabels = mvs, labels
+	a.MissingValuesPlaceholder = nil
 	for i := range a.ForeignKeys {
 		if err := processPlaceholder(a.ForeignKeys[i].FieldsPlaceholder, &a.ForeignKeys[i].Fields); err != nil {
 			return fmt.Errorf("foreignKeys.fields must be either a string or list")
@@ -385,6 +450,7 @@ func (s *Schema) MarshalJSON() ([]byte, error) {
 	type schemaAlias Schema
 	a := schemaAlias(*s)
 	a.PrimaryKeyPlaceholder = a.PrimaryKeys
+	a.MissingValuesPlaceholder = missingValuesPlaceholder(a.MissingValues, a.MissingValueLabels)
 	for i := range a.ForeignKeys {
 		a.ForeignKeys[i].Reference.FieldsPlaceholder = a.ForeignKeys[i].Reference.Fields
 	}
@@ -422,6 +488,16 @@ type RowConversionError struct {
 	Err        error
 }
 
+// Error returns a string version of the error, including the line number.
+func (e RowConversionError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.LineNumber, e.Err)
+}
+
+// Unwrap returns the error that happened while converting the row.
+func (e RowConversionError) Unwrap() error {
+	return e.Err
+}
+
 // ConversionError aggregates all errors that happened during a conversion operation (i.e., CastTable or
 // UncastTable).
 type ConversionError struct {
@@ -434,7 +510,9 @@ func (ce *ConversionError) Error() string {
 }
 
 // CastTable loads and casts all table rows in a best effort manner.
-// Line-by-line errors will be reported as *ConversionError type
+// Line-by-line errors will be reported as *ConversionError type. For instance, rows
+// missing values of required (or primary key) fields are reported with a *RequiredError.
+// Ragged rows skipped by the RaggedRows policy are not reported.
 //
 // The result argument must necessarily be the address for a slice. The slice
 // may be nil or previously allocated.
@@ -462,21 +540,30 @@ func (s *Schema) CastTable(tab table.Table, out interface{}) error {
 		rowIndex++
 		elemp := reflect.New(elemt)
 		if err := s.CastRow(iter.Row(), elemp.Interface()); err != nil {
+			var rerr *RaggedRowError
+			if errors.As(err, &rerr) && rerr.Skipped {
+				continue
+			}
 			cv.Errors = append(cv.Errors, RowConversionError{rowIndex, err})
 			continue
 		}
 
-		// Check unique field and other constraints.
+		// Check unique field and other constraints. Missing values are not
+		// considered duplicates.
+		row, _, _ := s.normalizeRow(iter.Row())
 		for _, k := range uniqueFieldIndexes {
-			field := elemp.Elem().Field(k)
-			if _, ok := uniqueCache[uniqueKey{k, field.Interface()}]; ok {
+			if _, ok := s.Fields[k].missingValueLabel(row[k], s); ok {
+				continue
+			}
+			key := uniqueKey{k, s.Fields[k].uniqueValue(row[k])}
+			if _, ok := uniqueCache[key]; ok {
 				cv.Errors = append(cv.Errors, RowConversionError{
 					rowIndex,
-					fmt.Errorf("field(s) '%s' duplicates in row %v", elemp.Elem().Type().Field(k).Name, rowIndex),
+					fmt.Errorf("field(s) '%s' duplicates in row %v", s.Fields[k].Name, rowIndex),
 				})
 				break
 			}
-			uniqueCache[uniqueKey{k, field.Interface()}] = struct{}{}
+			uniqueCache[key] = struct{}{}
 		}
 		slicev = reflect.Append(slicev, elemp.Elem())
 		slicev = slicev.Slice(0, slicev.Len())
@@ -492,6 +579,25 @@ func (s *Schema) CastTable(tab table.Table, out interface{}) error {
 	return &cv
 }
 
+// uniqueValue returns a comparable version of the cast value, so equal values
+// written differently (e.g. "1" and "1.0" in a number field) are duplicates.
+// Values which can not be used as map keys (e.g. binary, array or object values)
+// are compared by their JSON encoding.
+func (f *Field) uniqueValue(v string) interface{} {
+	c, err := f.Cast(v)
+	if err != nil {
+		return v
+	}
+	if c == nil || reflect.TypeOf(c).Comparable() {
+		return c
+	}
+	b, err := json.Marshal(c)
+	if err != nil {
+		return v
+	}
+	return string(b)
+}
+
 func extractUniqueFieldIndexes(s *Schema) []int {
 	uniqueIndexes := make(map[int]struct{})
 	for _, pk := range s.PrimaryKeys {
@@ -510,7 +616,9 @@ func extractUniqueFieldIndexes(s *Schema) []int {
 	return keys
 }
 
-// CastColumn loads and casts all rows from a single column.
+// CastColumn loads and casts all rows from a single column. Missing values of
+// required (or primary key) fields are reported as a RowConversionError wrapping
+// a *RequiredError.
 //
 // The result argument must necessarily be the address for a slice. The slice
 // may be nil or previously allocated.
@@ -526,7 +634,15 @@ func (s *Schema) CastColumn(col []string, name string, out interface{}) error {
 	slicev := outv.Elem()
 	slicev = slicev.Slice(0, 0)   // Trucantes the passed-in slice.
 	elemt := slicev.Type().Elem() // Last Elem() needed because the pointer type.
-	for _, v := range col {
+	for i, v := range col {
+		elem := reflect.New(elemt).Elem()
+		if _, ok := f.missingValueLabel(v, s); ok {
+			if s.isRequired(f) {
+				return RowConversionError{LineNumber: i, Err: &RequiredError{Field: f.Name, Value: v}}
+			}
+			slicev = reflect.Append(slicev, elem)
+			continue
+		}
 		cast, err := f.Cast(v)
 		if err != nil {
 			return fmt.Errorf("error casting column value(%s):%q", v, err)
@@ -536,7 +652,6 @@ func (s *Schema) CastColumn(col []string, name string, out interface{}) error {
 		if !toSetType.ConvertibleTo(elemt) {
 			return fmt.Errorf("value:%s field:%s - can not convert from %v to %v", v, f.Name, toSetType, elemt)
 		}
-		elem := reflect.New(elemt).Elem()
 		elem.Set(toSetValue.Convert(elemt))
 		slicev = reflect.Append(slicev, elem)
 		slicev = slicev.Slice(0, slicev.Len())
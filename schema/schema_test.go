@@ -2,6 +2,7 @@ package schema
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -334,6 +335,42 @@ func TestSchema_Cast(t *testing.T) {
 		want, _ := time.Parse(time.RFC3339Nano, "2021-11-28T14:51:05.35811Z")
 		is.Equal(t1.T, want)
 	})
+	t.Run("BinaryField", func(t *testing.T) {
+		is := is.New(t)
+		t1 := struct {
+			Data    []byte  `tableheader:"Data"`
+			DataPtr *[]byte `tableheader:"DataPtr"`
+		}{}
+		s := Schema{Fields: []Field{{Name: "Data", Type: StringType, Format: "binary"}, {Name: "DataPtr", Type: StringType, Format: "binary"}}}
+		is.NoErr(s.CastRow([]string{"aGVsbG8=", "d29ybGQ="}, &t1))
+		is.Equal(t1.Data, []byte("hello"))
+		is.Equal(*t1.DataPtr, []byte("world"))
+
+		row, err := s.UncastRow(t1)
+		is.NoErr(err)
+		is.Equal(row, []string{"aGVsbG8=", "d29ybGQ="})
+	})
+	t.Run("ListField", func(t *testing.T) {
+		is := is.New(t)
+		t1 := struct {
+			Tags  []string    `tableheader:"Tags"`
+			Ids   []int64     `tableheader:"Ids"`
+			Dates []time.Time `tableheader:"Dates"`
+		}{}
+		s := Schema{Fields: []Field{
+			{Name: "Tags", Type: ListType, Delimiter: ";"},
+			{Name: "Ids", Type: ListType, ItemType: IntegerType, Delimiter: ";"},
+			{Name: "Dates", Type: ListType, ItemType: DateType, Delimiter: ";"},
+		}}
+		is.NoErr(s.CastRow([]string{"a;b", "1;2", "2020-01-02"}, &t1))
+		is.Equal(t1.Tags, []string{"a", "b"})
+		is.Equal(t1.Ids, []int64{1, 2})
+		is.Equal(t1.Dates, []time.Time{time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC)})
+
+		row, err := s.UncastRow(t1)
+		is.NoErr(err)
+		is.Equal(row, []string{"a;b", "1;2", "2020-01-02T00:00:00Z"})
+	})
 	t.Run("StructPointerField", func(t *testing.T) {
 		is := is.New(t)
 		type EmbededT struct {
@@ -617,6 +654,53 @@ func TestCastTable(t *testing.T) {
 			t.Fatalf("err want:err got:nil")
 		}
 	})
+	t.Run("UniqueConstraintSkipsMissingValues", func(t *testing.T) {
+		is := is.New(t)
+		tab := table.FromSlices(
+			[]string{"ID", "Age"},
+			[][]string{{"1", ""}, {"2", ""}, {"3", "NA"}, {"4", "NA"}, {"5", "30"}})
+		s := &Schema{
+			Fields:        []Field{{Name: "ID", Type: IntegerType}, {Name: "Age", Type: IntegerType, Constraints: Constraints{Unique: true}}},
+			MissingValues: []string{"", "NA"},
+		}
+		type data struct {
+			ID  int
+			Age *int
+		}
+		got := []data{}
+		is.NoErr(s.CastTable(tab, &got))
+		is.Equal(len(got), 5)
+	})
+	t.Run("UniqueBinary", func(t *testing.T) {
+		is := is.New(t)
+		tab := table.FromSlices(
+			[]string{"ID", "Data"},
+			[][]string{{"1", "YQ=="}, {"2", "Yg=="}, {"3", "YQ=="}})
+		s := &Schema{Fields: []Field{{Name: "ID", Type: IntegerType}, {Name: "Data", Type: StringType, Format: "binary", Constraints: Constraints{Unique: true}}}}
+		type data struct {
+			ID   int
+			Data []byte
+		}
+		got := []data{}
+		err := s.CastTable(tab, &got)
+		var cErr *ConversionError
+		is.True(errors.As(err, &cErr))
+		is.Equal(len(cErr.Errors), 1)
+		is.Equal(cErr.Errors[0].LineNumber, 2)
+	})
+	t.Run("UniqueConstraintUsesSchemaOrder", func(t *testing.T) {
+		is := is.New(t)
+		tab := table.FromSlices(
+			[]string{"ID", "Name"},
+			[][]string{{"1", "Paul"}, {"2", "Paul"}})
+		s := &Schema{Fields: []Field{{Name: "ID", Type: IntegerType, Constraints: Constraints{Unique: true}}, {Name: "Name", Type: StringType}}}
+		type data struct {
+			Name string
+			ID   int
+		}
+		got := []data{}
+		is.NoErr(s.CastTable(tab, &got))
+	})
 	t.Run("Error_PrimaryKeyAndUniqueConstraint", func(t *testing.T) {
 		tab := table.FromSlices(
 			[]string{"ID", "Age", "Name"},
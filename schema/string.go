@@ -1,59 +1,233 @@
 package schema
 
 import (
+	"encoding/base64"
 	"fmt"
-	"github.com/satori/go.uuid"
+	"net"
 	"net/mail"
 	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
 )
 
 // Valid string formats and configuration.
 const (
-	stringURI         = "uri"
-	stringEmail       = "email"
-	stringUUID        = "uuid"
-	stringBinary      = "binary"
-	stringUUIDVersion = 4
+	stringURI            = "uri"
+	stringEmail          = "email"
+	stringUUID           = "uuid"
+	stringBinary         = "binary"
+	stringIPv4           = "ipv4"
+	stringIPv6           = "ipv6"
+	stringHostname       = "hostname"
+	stringDateTimeString = "date-time-string"
+	stringISOCountry     = "iso-country"
+	stringISOCurrency    = "iso-currency"
+	stringSemver         = "semver"
+	stringJSONPointer    = "json-pointer"
+	stringUUIDVersion    = 4
+	// maxUUIDVersion is the highest UUID version defined by RFC 9562.
+	maxUUIDVersion = 7
 )
 
-func checkStringConstraints(v string, c Constraints) error {
-	minLength := c.MinLength
-	maxLength := c.MaxLength
-	re := c.compiledPattern
+var (
+	hostnameLabelRegexp = regexp.MustCompile(`^(?i)[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+	// https://semver.org/#is-there-a-suggested-regular-expression-regex-to-check-a-semver-string
+	semverRegexp      = regexp.MustCompile(`^(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`)
+	jsonPointerRegexp = regexp.MustCompile(`^(/([^~/]|~[01])*)*$`)
+)
 
-	if minLength != 0 && len(v) < minLength {
-		return fmt.Errorf("constraint check error: %v %v < minimum:%v", v, len(v), minLength)
-	}
-	if maxLength != 0 && len(v) > maxLength {
-		return fmt.Errorf("constraint check error: %v %v > maximum:%v", v, len(v), maxLength)
-	}
+// FormatError is returned when a string value can not be validated against the
+// format of its field.
+type FormatError struct {
+	Format string
+	Value  string
+	Err    error
+}
 
+// Error returns a human readable description of the format violation.
+func (e *FormatError) Error() string {
+	return fmt.Sprintf("invalid %s value:\"%s\" - %v", e.Format, e.Value, e.Err)
+}
+
+// Unwrap returns the underlying validation error.
+func (e *FormatError) Unwrap() error {
+	return e.Err
+}
+
+// Encodings accepted by the binary string format. The standard alphabet is
+// tried first, which is also the one used when uncasting.
+var binaryEncodings = []*base64.Encoding{
+	base64.StdEncoding,
+	base64.URLEncoding,
+	base64.RawStdEncoding,
+	base64.RawURLEncoding,
+}
+
+func checkStringConstraints(v string, c Constraints) error {
+	if err := checkLengthConstraints(v, utf8.RuneCountInString(v), c); err != nil {
+		return err
+	}
+	re := c.compiledPattern
 	if re != nil && !re.MatchString(v) {
 		return fmt.Errorf("constraint check error: %v don't fit pattern : %v ", v, c.Pattern)
 	}
 	return nil
 }
 
-func castString(format, value string, c Constraints) (string, error) {
+func checkLengthConstraints(v interface{}, length int, c Constraints) error {
+	minLength := c.MinLength
+	maxLength := c.MaxLength
+	if minLength != 0 && length < minLength {
+		return fmt.Errorf("constraint check error: %v %v < minimum:%v", v, length, minLength)
+	}
+	if maxLength != 0 && length > maxLength {
+		return fmt.Errorf("constraint check error: %v %v > maximum:%v", v, length, maxLength)
+	}
+	return nil
+}
+
+// castString validates the value against the string constraints and format. The uuidVersions
+// argument lists the UUID versions accepted by the uuid format, where 0 stands for
+// the nil UUID. If empty, only version 4 UUIDs are accepted.
+func castString(format, value string, uuidVersions []int, c Constraints) (string, error) {
 	err := checkStringConstraints(value, c)
 	if err != nil {
 		return value, err
 	}
+	if err := checkStringFormat(format, value, uuidVersions); err != nil {
+		return value, &FormatError{Format: format, Value: value, Err: err}
+	}
+	return value, nil
+}
 
+func checkStringFormat(format, value string, uuidVersions []int) error {
 	switch format {
 	case stringURI:
 		_, err := url.ParseRequestURI(value)
-		return value, err
+		return err
 	case stringEmail:
 		_, err := mail.ParseAddress(value)
-		return value, err
+		return err
 	case stringUUID:
-		v, err := uuid.FromString(value)
-		if v.Version() != stringUUIDVersion {
-			return value, fmt.Errorf("invalid UUID version - got:%d want:%d", v.Version(), stringUUIDVersion)
+		return checkUUID(value, uuidVersions)
+	case stringIPv4:
+		ip := net.ParseIP(value)
+		if ip == nil || ip.To4() == nil || strings.Contains(value, ":") {
+			return fmt.Errorf("not an IPv4 address")
+		}
+	case stringIPv6:
+		ip := net.ParseIP(value)
+		if ip == nil || !strings.Contains(value, ":") {
+			return fmt.Errorf("not an IPv6 address")
+		}
+	case stringHostname:
+		h := strings.TrimSuffix(value, ".")
+		if len(h) == 0 || len(h) > 253 {
+			return fmt.Errorf("hostname must have between 1 and 253 characters")
+		}
+		for _, label := range strings.Split(h, ".") {
+			if !hostnameLabelRegexp.MatchString(label) {
+				return fmt.Errorf("invalid hostname label:\"%s\"", label)
+			}
+		}
+	case stringDateTimeString:
+		_, err := time.Parse(time.RFC3339, value)
+		return err
+	case stringISOCountry:
+		if _, ok := isoCountryCodes[value]; !ok {
+			return fmt.Errorf("not an ISO 3166-1 alpha-2 country code")
+		}
+	case stringISOCurrency:
+		if _, ok := isoCurrencyCodes[value]; !ok {
+			return fmt.Errorf("not an ISO 4217 currency code")
+		}
+	case stringSemver:
+		if !semverRegexp.MatchString(value) {
+			return fmt.Errorf("not a semantic version")
+		}
+	case stringJSONPointer:
+		if !jsonPointerRegexp.MatchString(value) {
+			return fmt.Errorf("not a JSON pointer")
 		}
-		return value, err
 	}
 	// NOTE: Returning the value for unknown format is in par with the python library.
-	return value, nil
+	return nil
+}
+
+const uuidPattern = `(?:urn:uuid:)?[0-9a-f]{8}-[0-9a-f]{4}-([0-9a-f])[0-9a-f]{3}-([0-9a-f])[0-9a-f]{3}-[0-9a-f]{12}`
+
+// uuidRegexp matches UUIDs with both braces or none. The version and variant digits are
+// submatches 1 and 2 of braced UUIDs, 3 and 4 of the others.
+var uuidRegexp = regexp.MustCompile(`^(?i)(?:\{` + uuidPattern + `\}|` + uuidPattern + `)$`)
+
+func checkUUID(value string, versions []int) error {
+	matches := uuidRegexp.FindStringSubmatch(value)
+	if matches == nil {
+		return fmt.Errorf("malformed UUID")
+	}
+	if len(versions) == 0 {
+		versions = []int{stringUUIDVersion}
+	}
+	// Version is the first hex digit of the third group, variant the
+	// two most significant bits of the fourth group.
+	if matches[1] == "" {
+		matches = matches[2:]
+	}
+	got, _ := strconv.ParseInt(matches[1], 16, 8)
+	variant, _ := strconv.ParseInt(matches[2], 16, 8)
+	hex := strings.TrimPrefix(strings.Trim(strings.ToLower(value), "{}"), "urn:uuid:")
+	if strings.Trim(hex, "0-") == "" {
+		got = 0
+	} else if got == 0 || got > maxUUIDVersion || variant&0xc != 0x8 {
+		return fmt.Errorf("not a RFC 4122 UUID")
+	}
+	for _, want := range versions {
+		if int(got) == want {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid UUID version - got:%d want one of:%v", got, versions)
+}
+
+// castBinary decodes a base64 string (standard or URL alphabet, padded or not).
+// The minLength and maxLength constraints are checked against the number of
+// decoded bytes, while pattern is checked against the encoded value.
+func castBinary(value string, c Constraints) ([]byte, error) {
+	var decoded []byte
+	var err error
+	for _, enc := range binaryEncodings {
+		decoded, err = enc.DecodeString(value)
+		if err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return nil, &FormatError{Format: stringBinary, Value: value, Err: err}
+	}
+	if err := checkLengthConstraints(value, len(decoded), c); err != nil {
+		return nil, err
+	}
+	if c.compiledPattern != nil && !c.compiledPattern.MatchString(value) {
+		return nil, fmt.Errorf("constraint check error: %v don't fit pattern : %v ", value, c.Pattern)
+	}
+	return decoded, nil
+}
+
+func uncastBinary(in interface{}) (string, error) {
+	switch v := in.(type) {
+	case []byte:
+		return base64.StdEncoding.EncodeToString(v), nil
+	case string:
+		// Strings are expected to be already encoded. Decoding them makes sure
+		// they are valid and normalizes the alphabet.
+		b, err := castBinary(v, noConstraints)
+		if err != nil {
+			return "", err
+		}
+		return base64.StdEncoding.EncodeToString(b), nil
+	}
+	return "", fmt.Errorf("invalid binary - value:%v type:%T", in, in)
 }
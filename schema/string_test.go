@@ -1,6 +1,7 @@
 package schema
 
 import (
+	"errors"
 	"regexp"
 	"testing"
 
@@ -10,17 +11,110 @@ import (
 // To be in par with the python library.
 func TestCastString_URIMustRequireScheme(t *testing.T) {
 	is := is.New(t)
-	_, err := castString(stringURI, "google.com", Constraints{})
+	_, err := castString(stringURI, "google.com", nil, Constraints{})
 	is.True(err != nil)
 }
 
 func TestCastString_InvalidUUIDVersion(t *testing.T) {
 	is := is.New(t)
 	// This is a uuid3: namespace DNS and python.org.
-	_, err := castString(stringUUID, "6fa459ea-ee8a-3ca4-894e-db77e160355e", Constraints{})
+	_, err := castString(stringUUID, "6fa459ea-ee8a-3ca4-894e-db77e160355e", nil, Constraints{})
 	is.True(err != nil)
 }
 
+func TestCastString_UUIDVersions(t *testing.T) {
+	data := []struct {
+		desc     string
+		value    string
+		versions []int
+	}{
+		{"V1", "a8098c1a-f86e-11da-bd1a-00112444be1e", []int{1}},
+		{"V3", "6fa459ea-ee8a-3ca4-894e-db77e160355e", []int{3, 4}},
+		{"V4Default", "C56A4180-65AA-42EC-A945-5FD21DEC0538", nil},
+		{"V5", "886313e1-3b8a-5372-9b90-0c9aee199e5d", []int{5}},
+		{"V6", "1ec9414c-232a-6b00-b3c8-9e6bdeced846", []int{6}},
+		{"V7", "017f22e2-79b0-7cc3-98c4-dc0c0c07398f", []int{7}},
+		{"Nil", "00000000-0000-0000-0000-000000000000", []int{0}},
+		{"Braced", "{C56A4180-65AA-42EC-A945-5FD21DEC0538}", nil},
+		{"URN", "urn:uuid:c56a4180-65aa-42ec-a945-5fd21dec0538", nil},
+	}
+	for _, d := range data {
+		t.Run(d.desc, func(t *testing.T) {
+			is := is.New(t)
+			_, err := castString(stringUUID, d.value, d.versions, Constraints{})
+			is.NoErr(err)
+		})
+	}
+	t.Run("Error", func(t *testing.T) {
+		data := []struct {
+			desc     string
+			value    string
+			versions []int
+		}{
+			{"Malformed", "6fa459ea-ee8a", nil},
+			{"VersionNotAccepted", "017f22e2-79b0-7cc3-98c4-dc0c0c07398f", []int{4}},
+			{"NilNotAccepted", "00000000-0000-0000-0000-000000000000", nil},
+			{"InvalidVariant", "6fa459ea-ee8a-4ca4-c94e-db77e160355e", []int{4}},
+			{"OnlyHexLetters", "dddddddd-dddd-dddd-dddd-dddddddddddd", []int{0}},
+			{"OpeningBraceOnly", "{c56a4180-65aa-42ec-a945-5fd21dec0538", nil},
+			{"ClosingBraceOnly", "c56a4180-65aa-42ec-a945-5fd21dec0538}", nil},
+		}
+		for _, d := range data {
+			t.Run(d.desc, func(t *testing.T) {
+				is := is.New(t)
+				_, err := castString(stringUUID, d.value, d.versions, Constraints{})
+				is.True(err != nil)
+			})
+		}
+	})
+}
+
+func TestCastString_Formats(t *testing.T) {
+	data := []struct {
+		desc   string
+		format string
+		value  string
+		valid  bool
+	}{
+		{"IPv4", stringIPv4, "192.168.0.1", true},
+		{"IPv4_Invalid", stringIPv4, "192.168.0.256", false},
+		{"IPv4_IPv6Value", stringIPv4, "::ffff:192.168.0.1", false},
+		{"IPv6", stringIPv6, "2001:db8::8a2e:370:7334", true},
+		{"IPv6_IPv4Value", stringIPv6, "192.168.0.1", false},
+		{"Hostname", stringHostname, "data.example.com", true},
+		{"Hostname_TrailingDot", stringHostname, "example.com.", true},
+		{"Hostname_Underscore", stringHostname, "my_host.com", false},
+		{"Hostname_LeadingHyphen", stringHostname, "-example.com", false},
+		{"DateTimeString", stringDateTimeString, "2026-10-17T10:00:00Z", true},
+		{"DateTimeString_Invalid", stringDateTimeString, "2026-10-17", false},
+		{"ISOCountry", stringISOCountry, "BR", true},
+		{"ISOCountry_Invalid", stringISOCountry, "XX", false},
+		{"ISOCountry_Lowercase", stringISOCountry, "br", false},
+		{"ISOCurrency", stringISOCurrency, "EUR", true},
+		{"ISOCurrency_Invalid", stringISOCurrency, "EURO", false},
+		{"Semver", stringSemver, "1.2.3-rc.1+build.5", true},
+		{"Semver_Invalid", stringSemver, "1.2", false},
+		{"JSONPointer", stringJSONPointer, "/a~1b/0", true},
+		{"JSONPointer_Root", stringJSONPointer, "", true},
+		{"JSONPointer_InvalidEscape", stringJSONPointer, "/a~2", false},
+		{"JSONPointer_NoSlash", stringJSONPointer, "a", false},
+	}
+	for _, d := range data {
+		t.Run(d.desc, func(t *testing.T) {
+			is := is.New(t)
+			_, err := castString(d.format, d.value, nil, Constraints{})
+			if d.valid {
+				is.NoErr(err)
+				return
+			}
+			var fe *FormatError
+			is.True(errors.As(err, &fe))
+			is.Equal(fe.Format, d.format)
+			is.Equal(fe.Value, d.value)
+		})
+	}
+}
+
 func TestCastString_ErrorCheckingConstraints(t *testing.T) {
 	data := []struct {
 		desc        string
@@ -41,7 +135,7 @@ func TestCastString_ErrorCheckingConstraints(t *testing.T) {
 	for _, d := range data {
 		t.Run(d.desc, func(t *testing.T) {
 			is := is.New(t)
-			_, err := castString(d.format, d.value, d.constraints)
+			_, err := castString(d.format, d.value, nil, d.constraints)
 			is.True(err != nil)
 		})
 	}
@@ -61,9 +155,79 @@ func TestCastString_Success(t *testing.T) {
 	for _, d := range data {
 		t.Run(d.desc, func(t *testing.T) {
 			is := is.New(t)
-			v, err := castString(d.format, d.value, d.constraints)
+			v, err := castString(d.format, d.value, nil, d.constraints)
 			is.NoErr(err)
 			is.Equal(v, d.value)
 		})
 	}
 }
+
+func TestCastBinary(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		data := []struct {
+			desc        string
+			value       string
+			constraints Constraints
+			want        []byte
+		}{
+			{"Standard", "aGVsbG8/Pz8=", Constraints{}, []byte("hello???")},
+			{"URL", "aGVsbG8_Pz8=", Constraints{}, []byte("hello???")},
+			{"RawStandard", "aGVsbG8", Constraints{}, []byte("hello")},
+			{"Empty", "", Constraints{}, []byte{}},
+			{"Length", "aGVsbG8=", Constraints{MinLength: 5, MaxLength: 5}, []byte("hello")},
+		}
+		for _, d := range data {
+			t.Run(d.desc, func(t *testing.T) {
+				is := is.New(t)
+				got, err := castBinary(d.value, d.constraints)
+				is.NoErr(err)
+				is.Equal(got, d.want)
+			})
+		}
+	})
+	t.Run("Error", func(t *testing.T) {
+		data := []struct {
+			desc        string
+			value       string
+			constraints Constraints
+		}{
+			{"NotBase64", "hello!", Constraints{}},
+			{"MinLength", "aGVsbG8=", Constraints{MinLength: 6}},
+			{"MaxLength", "aGVsbG8=", Constraints{MaxLength: 4}},
+			{"Pattern", "aGVsbG8=", Constraints{compiledPattern: regexp.MustCompile("^b.*"), Pattern: "^b.*"}},
+		}
+		for _, d := range data {
+			t.Run(d.desc, func(t *testing.T) {
+				is := is.New(t)
+				_, err := castBinary(d.value, d.constraints)
+				is.True(err != nil)
+			})
+		}
+	})
+}
+
+func TestUncastBinary(t *testing.T) {
+	data := []struct {
+		desc  string
+		value interface{}
+		want  string
+	}{
+		{"Bytes", []byte("hello???"), "aGVsbG8/Pz8="},
+		{"EncodedString", "aGVsbG8_Pz8=", "aGVsbG8/Pz8="},
+	}
+	for _, d := range data {
+		t.Run(d.desc, func(t *testing.T) {
+			is := is.New(t)
+			got, err := uncastBinary(d.value)
+			is.NoErr(err)
+			is.Equal(got, d.want)
+		})
+	}
+	t.Run("Error", func(t *testing.T) {
+		is := is.New(t)
+		_, err := uncastBinary(10)
+		is.True(err != nil)
+		_, err = uncastBinary("hello!")
+		is.True(err != nil)
+	})
+}
@@ -11,20 +11,7 @@ func castTime(format, value string, c Constraints) (time.Time, error) {
 	if err != nil {
 		return y, err
 	}
-	var max, min time.Time
-	if c.Maximum != "" {
-		max, err = castTimeWithoutCheckConstraints(format, c.Maximum)
-		if err != nil {
-			return y, err
-		}
-	}
-	if c.Minimum != "" {
-		min, err = castTimeWithoutCheckConstraints(format, c.Minimum)
-		if err != nil {
-			return y, err
-		}
-	}
-	return checkConstraints(y, max, min, TimeType)
+	return checkTimeConstraints(y, c, TimeType, func(v string) (time.Time, error) { return castTimeWithoutCheckConstraints(format, v) })
 }
 
 func castTimeWithoutCheckConstraints(format, value string) (time.Time, error) {
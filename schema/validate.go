@@ -0,0 +1,536 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DescriptorError describes a single problem found in a schema descriptor.
+type DescriptorError struct {
+	// Pointer is the JSON pointer (RFC 6901) to the offending property, for
+	// instance "/fields/0/type". It is empty if the problem concerns the whole descriptor.
+	Pointer string
+	Message string
+}
+
+func (e DescriptorError) Error() string {
+	if e.Pointer == "" {
+		return e.Message
+	}
+	return fmt.Sprintf("%s: %s", e.Pointer, e.Message)
+}
+
+// ValidationError is returned by Schema.Validate and ValidateDescriptor. It lists
+// all the problems found in the schema, in descriptor order.
+type ValidationError struct {
+	Errors []DescriptorError
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i := range e.Errors {
+		msgs[i] = e.Errors[i].Error()
+	}
+	return fmt.Sprintf("invalid schema: %s", strings.Join(msgs, "; "))
+}
+
+// validator accumulates the problems found in a schema descriptor.
+type validator struct {
+	errs []DescriptorError
+}
+
+func (v *validator) addf(pointer, format string, a ...interface{}) {
+	v.errs = append(v.errs, DescriptorError{Pointer: pointer, Message: fmt.Sprintf(format, a...)})
+}
+
+func (v *validator) err() error {
+	if len(v.errs) == 0 {
+		return nil
+	}
+	return &ValidationError{Errors: v.errs}
+}
+
+// pointerToken escapes a property name to be used as JSON pointer reference token.
+func pointerToken(s string) string {
+	return strings.Replace(strings.Replace(s, "~", "~0", -1), "/", "~1", -1)
+}
+
+// stringFormats holds the formats supported by the string type.
+var stringFormats = map[string]struct{}{
+	stringURI: {}, stringEmail: {}, stringUUID: {}, stringBinary: {}, stringIPv4: {}, stringIPv6: {},
+	stringHostname: {}, stringDateTimeString: {}, stringISOCountry: {}, stringISOCurrency: {},
+	stringSemver: {}, stringJSONPointer: {},
+}
+
+// listItemTypes holds the types supported as list items.
+var listItemTypes = map[FieldType]struct{}{
+	StringType: {}, IntegerType: {}, NumberType: {}, BooleanType: {}, DateType: {}, DateTimeType: {}, TimeType: {},
+}
+
+// checkFormat returns an error if the format is not supported by the field type.
+func checkFormat(t FieldType, format string) error {
+	if format == "" || format == defaultFieldFormat {
+		return nil
+	}
+	// Formats of custom types are interpreted by their casters.
+	if _, ok := customCaster(t); ok {
+		return nil
+	}
+	switch t {
+	case StringType:
+		if _, ok := stringFormats[format]; ok {
+			return nil
+		}
+	case GeoPointType:
+		if format == GeoPointArrayFormat || format == GeoPointObjectFormat {
+			return nil
+		}
+	case DateType, TimeType, DateTimeType:
+		// Custom formats follow the strftime/strptime syntax.
+		if format == AnyDateFormat || strings.Contains(format, "%") {
+			return nil
+		}
+	}
+	return fmt.Errorf("format %q is not supported by type %s", format, t)
+}
+
+func (v *validator) schema(s *Schema) {
+	names := make(map[string]int, len(s.Fields))
+	for i := range s.Fields {
+		p := fmt.Sprintf("/fields/%d", i)
+		f := &s.Fields[i]
+		if f.Name == "" {
+			v.addf(p+"/name", "attribute name is mandatory")
+		} else if j, ok := names[f.Name]; ok {
+			v.addf(p+"/name", "duplicate field name %q, also used by /fields/%d", f.Name, j)
+		} else {
+			names[f.Name] = i
+		}
+		v.field(p, f)
+	}
+	pks := make(map[string]struct{}, len(s.PrimaryKeys))
+	for i, pk := range s.PrimaryKeys {
+		p := fmt.Sprintf("/primaryKey/%d", i)
+		if !s.HasField(pk) {
+			v.addf(p, "there is no field %s", pk)
+		}
+		if _, ok := pks[pk]; ok {
+			v.addf(p, "duplicate primary key field %s", pk)
+		}
+		pks[pk] = struct{}{}
+	}
+	for i, fk := range s.ForeignKeys {
+		p := fmt.Sprintf("/foreignKeys/%d", i)
+		for j, f := range fk.Fields {
+			if !s.HasField(f) {
+				v.addf(fmt.Sprintf("%s/fields/%d", p, j), "there is no field %s", f)
+			}
+		}
+		if len(fk.Reference.Fields) != len(fk.Fields) {
+			v.addf(p+"/reference/fields", "foreignKey.fields must contain the same number entries as foreignKey.reference.fields")
+		}
+		// An empty resource references the schema itself.
+		if fk.Reference.Resource == "" {
+			for j, f := range fk.Reference.Fields {
+				if !s.HasField(f) {
+					v.addf(fmt.Sprintf("%s/reference/fields/%d", p, j), "there is no field %s", f)
+				}
+			}
+		}
+	}
+}
+
+// field validates the field descriptor located at the pointer p.
+func (v *validator) field(p string, f *Field) {
+	t := f.Type
+	if t == "" {
+		t = defaultFieldType
+	}
+	if !isKnownType(t) {
+		v.addf(p+"/type", "unknown type %q", t)
+		return
+	}
+	if err := checkFormat(t, f.Format); err != nil {
+		v.addf(p+"/format", "%v", err)
+	}
+	for i, u := range f.UUIDVersions {
+		if u < 0 || u > maxUUIDVersion {
+			v.addf(fmt.Sprintf("%s/uuidVersions/%d", p, i), "invalid UUID version %d", u)
+		}
+	}
+	switch t {
+	case ListType:
+		if _, ok := listItemTypes[f.listItem().Type]; !ok {
+			v.addf(p+"/itemType", "unsupported list item type %q", f.ItemType)
+		}
+	case CategoricalType:
+		if len(f.Categories) == 0 {
+			v.addf(p+"/categories", "categorical fields must declare their categories")
+		}
+		seen := make(map[string]struct{}, len(f.Categories))
+		for i, c := range f.Categories {
+			if _, ok := seen[c.raw()]; ok {
+				v.addf(fmt.Sprintf("%s/categories/%d", p, i), "duplicate category %v", c.Value)
+			}
+			seen[c.raw()] = struct{}{}
+		}
+	case ArrayType:
+		if f.ArrayItem != nil {
+			v.field(p+"/arrayItem", f.ArrayItem)
+		}
+	case ObjectType:
+		props := make([]string, 0, len(f.Properties))
+		for k := range f.Properties {
+			props = append(props, k)
+		}
+		sort.Strings(props)
+		for _, k := range props {
+			prop := f.Properties[k]
+			v.field(p+"/properties/"+pointerToken(k), &prop)
+		}
+	}
+	f2 := *f
+	f2.Type = t
+	v.constraints(p+"/constraints", &f2)
+}
+
+// constraints validates the constraints of a field of known type.
+func (v *validator) constraints(p string, f *Field) {
+	inapplicable := make(map[string]struct{})
+	for _, name := range inapplicableConstraints(f) {
+		v.addf(p+"/"+name, "constraint %s is not applicable to type %s", name, f.Type)
+		inapplicable[name] = struct{}{}
+	}
+	c := f.Constraints
+	caster := f.boundCaster()
+	for _, b := range []struct{ name, value string }{
+		{minimumConstraint, c.Minimum},
+		{maximumConstraint, c.Maximum},
+		{exclusiveMinimumConstraint, c.ExclusiveMinimum},
+		{exclusiveMaximumConstraint, c.ExclusiveMaximum},
+	} {
+		if _, ok := inapplicable[b.name]; ok || b.value == "" {
+			continue
+		}
+		if _, err := caster.Cast(b.value); err != nil {
+			v.addf(p+"/"+b.name, "invalid value %q for type %s: %v", b.value, caster.Type, err)
+		}
+	}
+	if c.MinLength < 0 {
+		v.addf(p+"/"+minLengthConstraint, "must not be negative")
+	}
+	if c.MaxLength < 0 {
+		v.addf(p+"/"+maxLengthConstraint, "must not be negative")
+	}
+	if c.MaxLength > 0 && c.MinLength > c.MaxLength {
+		v.addf(p+"/"+minLengthConstraint, "must not be greater than maxLength")
+	}
+	if _, ok := inapplicable[patternConstraint]; !ok && c.Pattern != "" {
+		if _, err := regexp.Compile(c.Pattern); err != nil {
+			v.addf(p+"/"+patternConstraint, "%v", err)
+		}
+	}
+	if len(c.Enum) > 0 {
+		if _, err := f.compileEnum(); err != nil {
+			v.addf(p+"/"+enumConstraint, "%v", err)
+		}
+	}
+}
+
+// JSON kinds used to check the properties of raw descriptors.
+const (
+	jsonAny     = ""
+	jsonString  = "string"
+	jsonBound   = "string or number"
+	jsonBoolean = "boolean"
+	jsonInteger = "integer"
+	jsonArray   = "array"
+	jsonObject  = "object"
+)
+
+// Properties allowed in descriptors, along with their JSON kind.
+// More at: https://specs.frictionlessdata.io/schemas/table-schema.json
+var (
+	schemaProperties = map[string]string{
+		"$schema":       jsonString,
+		"fields":        jsonArray,
+		"primaryKey":    jsonAny,
+		"foreignKeys":   jsonArray,
+		"missingValues": jsonArray,
+	}
+	fieldProperties = map[string]string{
+		"name":              jsonString,
+		"type":              jsonString,
+		"format":            jsonString,
+		"title":             jsonString,
+		"description":       jsonString,
+		"example":           jsonAny,
+		"rdfType":           jsonString,
+		"trueValues":        jsonArray,
+		"falseValues":       jsonArray,
+		"decimalChar":       jsonString,
+		"groupChar":         jsonString,
+		"bareNumber":        jsonBoolean,
+		"uuidVersions":      jsonArray,
+		"arrayItem":         jsonObject,
+		"properties":        jsonObject,
+		"delimiter":         jsonString,
+		"itemType":          jsonString,
+		"categories":        jsonArray,
+		"categoriesOrdered": jsonBoolean,
+		"missingValues":     jsonArray,
+		"constraints":       jsonObject,
+	}
+	constraintProperties = map[string]string{
+		requiredConstraint:         jsonBoolean,
+		uniqueConstraint:           jsonBoolean,
+		minimumConstraint:          jsonBound,
+		maximumConstraint:          jsonBound,
+		exclusiveMinimumConstraint: jsonBound,
+		exclusiveMaximumConstraint: jsonBound,
+		minLengthConstraint:        jsonInteger,
+		maxLengthConstraint:        jsonInteger,
+		patternConstraint:          jsonString,
+		enumConstraint:             jsonArray,
+	}
+)
+
+func isJSONKind(v interface{}, kind string) bool {
+	switch kind {
+	case jsonString:
+		_, ok := v.(string)
+		return ok
+	case jsonBound:
+		switch v.(type) {
+		case string, float64:
+			return true
+		}
+		return false
+	case jsonBoolean:
+		_, ok := v.(bool)
+		return ok
+	case jsonInteger:
+		n, ok := v.(float64)
+		return ok && n == math.Trunc(n)
+	case jsonArray:
+		_, ok := v.([]interface{})
+		return ok
+	case jsonObject:
+		_, ok := v.(map[string]interface{})
+		return ok
+	}
+	return true
+}
+
+// object checks that the raw value at pointer p is a JSON object which only holds
+// the passed-in properties, each one of the expected kind. Returns the object, or
+// nil if the value is not an object.
+func (v *validator) object(p string, raw interface{}, props map[string]string) map[string]interface{} {
+	obj, ok := raw.(map[string]interface{})
+	if !ok {
+		v.addf(p, "must be an object")
+		return nil
+	}
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		kind, ok := props[k]
+		switch {
+		case !ok:
+			v.addf(p+"/"+pointerToken(k), "unknown property")
+		case !isJSONKind(obj[k], kind):
+			v.addf(p+"/"+pointerToken(k), "must be of type %s", kind)
+		}
+	}
+	return obj
+}
+
+func (v *validator) rawField(p string, raw interface{}) {
+	obj := v.object(p, raw, fieldProperties)
+	if obj == nil {
+		return
+	}
+	if c, ok := obj["constraints"].(map[string]interface{}); ok {
+		v.object(p+"/constraints", c, constraintProperties)
+	}
+	if item, ok := obj["arrayItem"].(map[string]interface{}); ok {
+		v.rawField(p+"/arrayItem", item)
+	}
+	if props, ok := obj["properties"].(map[string]interface{}); ok {
+		keys := make([]string, 0, len(props))
+		for k := range props {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			v.rawField(p+"/properties/"+pointerToken(k), props[k])
+		}
+	}
+}
+
+func (v *validator) rawSchema(raw interface{}) {
+	obj := v.object("", raw, schemaProperties)
+	if obj == nil {
+		return
+	}
+	fields, ok := obj["fields"]
+	if !ok {
+		v.addf("/fields", "attribute fields is mandatory")
+		return
+	}
+	if fields, ok := fields.([]interface{}); ok {
+		for i, f := range fields {
+			v.rawField("/fields/"+strconv.Itoa(i), f)
+		}
+	}
+}
+
+// reported returns true if a problem was found at the pointer p or inside it.
+func (v *validator) reported(p string) bool {
+	for _, e := range v.errs {
+		if e.Pointer == p || strings.HasPrefix(e.Pointer, p+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// decodeSchema decodes the raw descriptor without giving up on the first problem, so
+// the validator reports them all. Missing values and fields which can not be decoded
+// are reported and left empty.
+func (v *validator) decodeSchema(obj map[string]interface{}) *Schema {
+	rest := make(map[string]interface{}, len(obj))
+	for k, val := range obj {
+		if k != "fields" {
+			rest[k] = val
+		}
+	}
+	if mv, ok := rest["missingValues"]; ok {
+		if _, _, err := parseMissingValues(mv); err != nil {
+			v.addf("/missingValues", "%v", err)
+			delete(rest, "missingValues")
+		}
+	}
+	var s Schema
+	if err := decodeRaw(rest, &s); err != nil {
+		s = Schema{}
+		if !v.reported("/primaryKey") && !v.reported("/foreignKeys") && !v.reported("/missingValues") {
+			v.addf("", "%v", err)
+		}
+	}
+	fields, _ := obj["fields"].([]interface{})
+	for i, f := range fields {
+		s.Fields = append(s.Fields, v.decodeField("/fields/"+strconv.Itoa(i), f))
+	}
+	s.propagateMissingValues()
+	return &s
+}
+
+// decodeField decodes the raw field descriptor located at the pointer p. Unlike
+// Field.UnmarshalJSON, it does not compile the field, so problems of the pattern and
+// enum constraints are reported by the validator, along with the other ones.
+func (v *validator) decodeField(p string, raw interface{}) Field {
+	f := withDefaults()
+	obj, ok := raw.(map[string]interface{})
+	if !ok {
+		return f
+	}
+	rest := make(map[string]interface{}, len(obj))
+	for k, val := range obj {
+		if k != "arrayItem" && k != "properties" {
+			rest[k] = val
+		}
+	}
+	type fieldAlias Field
+	u := fieldAlias(f)
+	if err := decodeRaw(rest, &u); err != nil {
+		if !v.reported(p) {
+			v.addf(p, "%v", err)
+		}
+		// The name is kept, so duplicates are still reported.
+		f.Name, _ = obj["name"].(string)
+		return f
+	}
+	f = Field(u)
+	if f.MissingValuesPlaceholder != nil {
+		values, labels, err := parseMissingValues(f.MissingValuesPlaceholder)
+		if err != nil {
+			v.addf(p+"/missingValues", "%v", err)
+		} else {
+			f.MissingValues = make(map[string]struct{}, len(values))
+			for _, mv := range values {
+				f.MissingValues[mv] = struct{}{}
+			}
+			f.MissingValueLabels = labels
+		}
+	}
+	if item, ok := obj["arrayItem"]; ok {
+		i := v.decodeField(p+"/arrayItem", item)
+		f.ArrayItem = &i
+	}
+	if props, ok := obj["properties"].(map[string]interface{}); ok {
+		keys := make([]string, 0, len(props))
+		for k := range props {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		f.Properties = make(map[string]Field, len(props))
+		for _, k := range keys {
+			pf := v.decodeField(p+"/properties/"+pointerToken(k), props[k])
+			pf.Name = k
+			f.Properties[k] = pf
+		}
+	}
+	return f
+}
+
+// decodeRaw decodes the raw JSON value into out.
+func decodeRaw(raw interface{}, out interface{}) error {
+	buf, err := json.Marshal(raw)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(buf, out)
+}
+
+// ValidateDescriptor checks whether the passed-in schema descriptor is valid. On
+// top of the checks performed by Schema.Validate, it reports unknown properties,
+// properties holding the wrong JSON type and invalid patterns, enums and missing
+// values. If the descriptor is not valid, returns a *ValidationError listing all
+// problems found.
+// More at: https://specs.frictionlessdata.io/table-schema/
+func ValidateDescriptor(r io.Reader) error {
+	buf, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	var raw interface{}
+	if err := json.Unmarshal(buf, &raw); err != nil {
+		return err
+	}
+	var v validator
+	v.rawSchema(raw)
+	obj, ok := raw.(map[string]interface{})
+	if !ok {
+		return v.err()
+	}
+	v.schema(v.decodeSchema(obj))
+	if len(v.errs) == 0 {
+		// Problems not found by the validator are still reported.
+		if _, err := Read(bytes.NewReader(buf)); err != nil {
+			v.addf("", "%v", err)
+		}
+	}
+	return v.err()
+}
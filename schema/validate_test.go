@@ -0,0 +1,159 @@
+package schema
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func pointers(err error) []string {
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		return nil
+	}
+	var ret []string
+	for _, e := range verr.Errors {
+		ret = append(ret, e.Pointer)
+	}
+	return ret
+}
+
+func TestValidate_Pointers(t *testing.T) {
+	data := []struct {
+		Desc   string
+		Schema Schema
+		Want   []string
+	}{
+		{"UnknownType", Schema{Fields: []Field{{Name: "a", Type: "integr"}}}, []string{"/fields/0/type"}},
+		{"InvalidStringFormat", Schema{Fields: []Field{{Name: "a", Format: "url"}}}, []string{"/fields/0/format"}},
+		{"InvalidIntegerFormat", Schema{Fields: []Field{{Name: "a", Type: IntegerType, Format: "hex"}}}, []string{"/fields/0/format"}},
+		{"InvalidBound", Schema{Fields: []Field{{Name: "a", Type: IntegerType, Constraints: Constraints{Maximum: "abc"}}}}, []string{"/fields/0/constraints/maximum"}},
+		{"InvalidDateBound", Schema{Fields: []Field{{Name: "a", Type: DateType, Constraints: Constraints{ExclusiveMinimum: "2015/01/01"}}}}, []string{"/fields/0/constraints/exclusiveMinimum"}},
+		{"InvalidListItemBound", Schema{Fields: []Field{{Name: "a", Type: ListType, ItemType: IntegerType, Constraints: Constraints{Minimum: "a"}}}}, []string{"/fields/0/constraints/minimum"}},
+		{"InapplicableConstraint", Schema{Fields: []Field{{Name: "a", Type: BooleanType, Constraints: Constraints{Maximum: "1"}}}}, []string{"/fields/0/constraints/maximum"}},
+		{"InvalidPattern", Schema{Fields: []Field{{Name: "a", Constraints: Constraints{Pattern: "("}}}}, []string{"/fields/0/constraints/pattern"}},
+		{"InvalidEnum", Schema{Fields: []Field{{Name: "a", Type: IntegerType, Constraints: Constraints{Enum: []interface{}{"a"}}}}}, []string{"/fields/0/constraints/enum"}},
+		{"NegativeLength", Schema{Fields: []Field{{Name: "a", Constraints: Constraints{MinLength: -1}}}}, []string{"/fields/0/constraints/minLength"}},
+		{"MinLengthGreaterThanMax", Schema{Fields: []Field{{Name: "a", Constraints: Constraints{MinLength: 2, MaxLength: 1}}}}, []string{"/fields/0/constraints/minLength"}},
+		{"InvalidItemType", Schema{Fields: []Field{{Name: "a", Type: ListType, ItemType: ObjectType}}}, []string{"/fields/0/itemType"}},
+		{"NoCategories", Schema{Fields: []Field{{Name: "a", Type: CategoricalType}}}, []string{"/fields/0/categories"}},
+		{"DuplicateCategory", Schema{Fields: []Field{{Name: "a", Type: CategoricalType, Categories: []Category{{Value: "a"}, {Value: "a"}}}}}, []string{"/fields/0/categories/1"}},
+		{"InvalidUUIDVersion", Schema{Fields: []Field{{Name: "a", Format: stringUUID, UUIDVersions: []int{4, 9}}}}, []string{"/fields/0/uuidVersions/1"}},
+		{"InvalidArrayItem", Schema{Fields: []Field{{Name: "a", Type: ArrayType, ArrayItem: &Field{Type: "foo"}}}}, []string{"/fields/0/arrayItem/type"}},
+		{"InvalidProperty", Schema{Fields: []Field{{Name: "a", Type: ObjectType, Properties: map[string]Field{"b/c": {Type: "foo"}}}}}, []string{"/fields/0/properties/b~1c/type"}},
+		{"DuplicateName", Schema{Fields: []Field{{Name: "a"}, {Name: "a"}}}, []string{"/fields/1/name"}},
+		{"DuplicatePrimaryKey", Schema{Fields: []Field{{Name: "a"}}, PrimaryKeys: []string{"a", "a"}}, []string{"/primaryKey/1"}},
+		{"SelfReference", Schema{Fields: []Field{{Name: "a"}},
+			ForeignKeys: []ForeignKeys{{Fields: []string{"a"}, Reference: ForeignKeyReference{Fields: []string{"b"}}}}},
+			[]string{"/foreignKeys/0/reference/fields/0"},
+		},
+		{"AllProblems", Schema{
+			Fields: []Field{
+				{Type: IntegerType},
+				{Name: "b", Type: "integr"},
+				{Name: "c", Type: IntegerType, Constraints: Constraints{Maximum: "abc", MinLength: 1}},
+			},
+			PrimaryKeys: []string{"d"},
+			ForeignKeys: []ForeignKeys{{Fields: []string{"e"}}},
+		}, []string{
+			"/fields/0/name",
+			"/fields/1/type",
+			"/fields/2/constraints/minLength",
+			"/fields/2/constraints/maximum",
+			"/primaryKey/0",
+			"/foreignKeys/0/fields/0",
+			"/foreignKeys/0/reference/fields",
+		}},
+	}
+	for _, d := range data {
+		t.Run(d.Desc, func(t *testing.T) {
+			is := is.New(t)
+			is.Equal(pointers(d.Schema.Validate()), d.Want)
+		})
+	}
+}
+
+func TestValidate_ValidFormatsAndConstraints(t *testing.T) {
+	is := is.New(t)
+	s := Schema{Fields: []Field{
+		{Name: "a", Format: stringEmail, Constraints: Constraints{MinLength: 1, MaxLength: 2, Pattern: ".*"}},
+		{Name: "b", Type: DateType, Format: "%d/%m/%Y", Constraints: Constraints{Minimum: "01/01/2015"}},
+		{Name: "c", Type: GeoPointType, Format: GeoPointArrayFormat},
+		{Name: "d", Type: NumberType, Constraints: Constraints{ExclusiveMaximum: "1.5", Enum: []interface{}{"1"}}},
+		{Name: "e", Type: DurationType, Constraints: Constraints{Maximum: "P1D"}},
+		{Name: "f", Type: ListType, ItemType: DateType, Constraints: Constraints{Maximum: "2015-01-01", MaxLength: 3}},
+		{Name: "g", Type: CategoricalType, Categories: []Category{{Value: "a"}, {Value: "b"}}},
+	}}
+	is.NoErr(s.Validate())
+}
+
+func TestValidateDescriptor(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		is := is.New(t)
+		is.NoErr(ValidateDescriptor(strings.NewReader(`{
+			"fields":[
+				{"name":"a", "type":"integer", "constraints":{"required":true, "maximum":"10"}},
+				{"name":"b", "type":"object", "properties":{"c":{"type":"string"}}}
+			],
+			"primaryKey":"a",
+			"missingValues":["", "NA"]
+		}`)))
+	})
+	t.Run("NumericBounds", func(t *testing.T) {
+		is := is.New(t)
+		d := `{"fields":[
+			{"name":"a", "type":"integer", "constraints":{"minimum":-1, "maximum":10}},
+			{"name":"b", "type":"number", "constraints":{"exclusiveMinimum":0.5, "exclusiveMaximum":1e3}}
+		]}`
+		is.NoErr(ValidateDescriptor(strings.NewReader(d)))
+		s, err := Read(strings.NewReader(d))
+		is.NoErr(err)
+		is.Equal(s.Fields[0].Constraints.Maximum, "10")
+		_, err = s.Fields[0].Cast("11")
+		is.True(err != nil)
+		_, err = s.Fields[1].Cast("0.5")
+		is.True(err != nil)
+		_, err = s.Fields[1].Cast("999.5")
+		is.NoErr(err)
+	})
+	data := []struct {
+		Desc       string
+		Descriptor string
+		Want       []string
+	}{
+		{"NotAnObject", `[]`, []string{""}},
+		{"NoFields", `{}`, []string{"/fields"}},
+		{"UnknownSchemaProperty", `{"fields":[], "foo":1}`, []string{"/foo"}},
+		{"UnknownFieldProperty", `{"fields":[{"name":"a", "typ":"integer"}]}`, []string{"/fields/0/typ"}},
+		{"UnknownConstraint", `{"fields":[{"name":"a", "constraints":{"max":"1"}}]}`, []string{"/fields/0/constraints/max"}},
+		{"WrongJSONType", `{"fields":[{"name":"a", "type":1, "constraints":{"minLength":1.5}}]}`, []string{"/fields/0/type", "/fields/0/constraints/minLength"}},
+		{"WrongBoundJSONType", `{"fields":[{"name":"a", "type":"integer", "constraints":{"maximum":true}}]}`, []string{"/fields/0/constraints/maximum"}},
+		{"Nested", `{"fields":[{"name":"a", "type":"object", "properties":{"b":{"foo":1}}}]}`, []string{"/fields/0/properties/b/foo"}},
+		{"Merged", `{"fields":[{"name":"a", "type":"integr", "foo":1}, {"name":"a"}]}`, []string{"/fields/0/foo", "/fields/0/type", "/fields/1/name"}},
+		{"ReadError", `{"fields":[], "primaryKey":1}`, []string{""}},
+		{"ReadErrorAndUnknownProperty", `{"fields":[{"name":"a", "foo":1}], "primaryKey":1}`, []string{"/fields/0/foo", ""}},
+		{"AllProblems", `{"fields":[
+			{"name":"a", "type":"string", "constraints":{"pattern":"[a-"}},
+			{"name":"b", "type":"integer", "constraints":{"enum":["x"], "maximum":"abc"}},
+			{"name":"c", "type":"integr", "missingValues":[1]},
+			{"name":"a", "type":"array", "arrayItem":{"type":"string", "constraints":{"pattern":"("}}}
+		], "missingValues":[true]}`, []string{
+			"/missingValues",
+			"/fields/2/missingValues",
+			"/fields/0/constraints/pattern",
+			"/fields/1/constraints/maximum",
+			"/fields/1/constraints/enum",
+			"/fields/2/type",
+			"/fields/3/name",
+			"/fields/3/arrayItem/constraints/pattern",
+		}},
+	}
+	for _, d := range data {
+		t.Run(d.Desc, func(t *testing.T) {
+			is := is.New(t)
+			is.Equal(pointers(ValidateDescriptor(strings.NewReader(d.Descriptor))), d.Want)
+		})
+	}
+}